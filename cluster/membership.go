@@ -0,0 +1,189 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Cluster Membership
+//
+// pluginapi.Cluster 的参考实现，由主程序根据集群协议的结果更新
+// 同一节点上的所有插件共享一个 Membership
+//
+// 使用方法：
+//   m := cluster.New("node-1", "10.0.0.1:7946")   // 单节点，本节点为 leader
+//   m.Watch("report", reportPlugin)               // 插件实现了 pluginapi.TopologyWatcher 时
+//   m.Join("node-2", "10.0.0.2:7946")
+//   m.SetLeader("node-2")
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+var (
+	// ErrInvalidNode 节点 ID 为空
+	ErrInvalidNode = errors.New("invalid node id")
+	// ErrNodeExists 节点已在集群中
+	ErrNodeExists = errors.New("node already in cluster")
+	// ErrUnknownNode 节点不在集群中
+	ErrUnknownNode = errors.New("node not in cluster")
+	// ErrLeaveSelf 本节点不能离开自己的视图
+	ErrLeaveSelf = errors.New("local node cannot leave")
+)
+
+// Membership 本节点看到的集群成员与 leader
+type Membership struct {
+	self string
+
+	mu       sync.RWMutex
+	members  map[string]string // id -> address
+	leader   string
+	watchers map[string]pluginapi.TopologyWatcher
+}
+
+var _ pluginapi.Cluster = (*Membership)(nil)
+
+// New 创建只包含本节点的集群视图，本节点为 leader
+func New(self, addr string) *Membership {
+	return &Membership{
+		self:     self,
+		members:  map[string]string{self: addr},
+		leader:   self,
+		watchers: make(map[string]pluginapi.TopologyWatcher),
+	}
+}
+
+// NodeID 本节点 ID
+func (m *Membership) NodeID() string {
+	return m.self
+}
+
+// Members 当前成员（按 ID 排序）
+func (m *Membership) Members() []pluginapi.Member {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make([]pluginapi.Member, 0, len(m.members))
+	for id, addr := range m.members {
+		list = append(list, pluginapi.Member{
+			ID:      id,
+			Address: addr,
+			Leader:  id == m.leader,
+			Self:    id == m.self,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Leader 当前 leader，选举期间为空
+func (m *Membership) Leader() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.leader
+}
+
+// IsLeader 本节点是否为 leader
+func (m *Membership) IsLeader() bool {
+	return m.Leader() == m.self
+}
+
+// Watch 登记插件的拓扑变化回调
+func (m *Membership) Watch(plugin string, w pluginapi.TopologyWatcher) {
+	m.mu.Lock()
+	m.watchers[plugin] = w
+	m.mu.Unlock()
+}
+
+// Unwatch 移除插件的回调，插件关闭前调用
+func (m *Membership) Unwatch(plugin string) {
+	m.mu.Lock()
+	delete(m.watchers, plugin)
+	m.mu.Unlock()
+}
+
+// Join 节点加入集群
+func (m *Membership) Join(id, addr string) error {
+	if id == "" {
+		return ErrInvalidNode
+	}
+	m.mu.Lock()
+	if _, ok := m.members[id]; ok {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrNodeExists, id)
+	}
+	m.members[id] = addr
+	m.mu.Unlock()
+
+	m.notify(pluginapi.MemberJoined, id)
+	return nil
+}
+
+// Leave 节点离开集群；离开的是 leader 时进入选举状态（leader 为空）
+func (m *Membership) Leave(id string) error {
+	if id == m.self {
+		return ErrLeaveSelf
+	}
+	m.mu.Lock()
+	if _, ok := m.members[id]; !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrUnknownNode, id)
+	}
+	delete(m.members, id)
+	lostLeader := m.leader == id
+	if lostLeader {
+		m.leader = ""
+	}
+	m.mu.Unlock()
+
+	m.notify(pluginapi.MemberLeft, id)
+	if lostLeader {
+		m.notify(pluginapi.LeaderChanged, "")
+	}
+	return nil
+}
+
+// SetLeader 设置 leader，id 为空表示进入选举状态
+func (m *Membership) SetLeader(id string) error {
+	m.mu.Lock()
+	if id != "" {
+		if _, ok := m.members[id]; !ok {
+			m.mu.Unlock()
+			return fmt.Errorf("%w: %s", ErrUnknownNode, id)
+		}
+	}
+	if m.leader == id {
+		m.mu.Unlock()
+		return nil
+	}
+	m.leader = id
+	m.mu.Unlock()
+
+	m.notify(pluginapi.LeaderChanged, id)
+	return nil
+}
+
+// notify 在锁外按插件名称顺序投递事件
+func (m *Membership) notify(typ pluginapi.TopologyEventType, node string) {
+	m.mu.RLock()
+	ev := pluginapi.TopologyEvent{
+		Type:     typ,
+		Node:     node,
+		Leader:   m.leader,
+		IsLeader: m.leader == m.self,
+	}
+	plugins := make([]string, 0, len(m.watchers))
+	for plugin := range m.watchers {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+	watchers := make([]pluginapi.TopologyWatcher, len(plugins))
+	for i, plugin := range plugins {
+		watchers[i] = m.watchers[plugin]
+	}
+	m.mu.RUnlock()
+
+	for _, w := range watchers {
+		w.OnTopologyChange(ev)
+	}
+}
@@ -2,18 +2,22 @@
 // AXMQ Plugin SDK - Auth Plugin Example
 //
 // 示例：自定义认证插件
-// 演示如何实现 OnAuth 钩子，对接外部用户系统
+// 演示如何实现 OnAuth 钩子，对接外部用户系统，以及在不重新加载的情况下更新用户列表
 //
-// 构建（Linux amd64）：go run ../../tools/build/main.go -dir . -output ./auth_plugin.so -goos linux -goarch amd64 -v
-// 测试（Linux amd64 环境）：go run ../../runner/main.go -plugin ./auth_plugin.so
+// 构建（Linux amd64）：go run ../../tools/build -dir . -output ./auth_plugin.so -goos linux -goarch amd64 -v
+// 测试（Linux amd64 环境）：go run ../../runner -plugin ./auth_plugin.so
 
 package main
 
 import (
+	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
@@ -23,70 +27,127 @@ import (
 type AuthPlugin struct {
 	pluginapi.BasePlugin // 嵌入 BasePlugin 获得默认实现
 
-	// 配置
-	users map[string]string // username -> password
+	// 配置（Reconfigure 时整体替换，钩子并发读取）
+	users atomic.Pointer[map[string]string] // username -> password
+	log   *slog.Logger
 }
 
 // 确保实现了 Plugin 接口
-var _ pluginapi.Plugin = (*AuthPlugin)(nil)
+var (
+	_ pluginapi.Plugin          = (*AuthPlugin)(nil)
+	_ pluginapi.HostInitializer = (*AuthPlugin)(nil)
+	_ pluginapi.Reconfigurable  = (*AuthPlugin)(nil)
+)
+
+// configSchema 配置 Schema，主程序在 Init / Reconfigure 之前校验，构建工具同时写入 .meta.json
+//
+//go:embed config.schema.json
+var configSchema []byte
 
 // NewPlugin 插件工厂函数（必须导出）
 func NewPlugin() pluginapi.Plugin {
-	return &AuthPlugin{
-		users: make(map[string]string),
-	}
+	return &AuthPlugin{}
 }
 
 // Info 返回插件元信息
 func (p *AuthPlugin) Info() pluginapi.PluginMeta {
 	return pluginapi.PluginMeta{
-		Name:       "auth_plugin",
-		Version:    "1.0.0",
-		SDKVersion: pluginapi.SDKVersion,
-		GoVersion:  runtime.Version(),
-		BuildTime:  time.Now().Format(time.RFC3339),
+		Name:         "auth_plugin",
+		Version:      "1.0.0",
+		SDKVersion:   pluginapi.SDKVersion,
+		GoVersion:    runtime.Version(),
+		BuildTime:    time.Now().Format(time.RFC3339),
+		Description:  "基于用户名密码的认证示例",
+		Author:       "AXMQ Authors",
+		License:      "Apache-2.0",
+		Homepage:     "https://github.com/AXMQ-NET/axmq-plugin-sdk",
+		ConfigSchema: configSchema,
+		// OnPublish 只记录日志、不保留上下文，可以与其他插件共享池化的上下文，避免逐插件复制
+		SharedPublish: true,
 		// HookTimeout: 500 * time.Millisecond, // 如需数据库查询，可设置更长超时
+		// HookPolicies: pluginapi.HookPolicies{ // 也可以只放宽 OnAuth，并在认证后端不可用时放行
+		// 	pluginapi.HookAuth: {Timeout: 2 * time.Second, OnFailure: pluginapi.FailOpen},
+		// },
 	}
 }
 
-// Init 初始化插件
+// Init 初始化插件（主程序不提供 HostAPI 时使用，日志输出到标准 slog）
 func (p *AuthPlugin) Init(config []byte) error {
+	p.log = slog.Default().With(pluginapi.LogKeyPlugin, "auth_plugin")
+	return p.init(config)
+}
+
+// InitWithHost 初始化插件，日志交由主程序统一处理
+func (p *AuthPlugin) InitWithHost(host pluginapi.HostAPI, config []byte) error {
+	p.log = host.Logger()
+	return p.init(config)
+}
+
+func (p *AuthPlugin) init(config []byte) error {
 	// 默认用户（实际应用中应从配置或外部系统加载）
-	p.users["admin"] = "secret"
-	p.users["guest"] = "guest123"
+	users := map[string]string{
+		"admin": "secret",
+		"guest": "guest123",
+	}
 
-	// 如果有配置，解析配置
+	// 如果有配置，解析配置；无效配置使初始化失败，而不是悄悄使用默认用户
 	if len(config) > 0 {
-		var cfg struct {
-			Users map[string]string `json:"users"`
+		cfg, err := parseUsers(config)
+		if err != nil {
+			return fmt.Errorf("invalid config: %w", err)
 		}
-		if err := json.Unmarshal(config, &cfg); err == nil && len(cfg.Users) > 0 {
-			p.users = cfg.Users
+		if len(cfg) > 0 {
+			users = cfg
 		}
 	}
 
-	fmt.Printf("[auth_plugin] Initialized with %d users\n", len(p.users))
+	p.users.Store(&users)
+	p.log.Info("initialized", "users", len(users))
 	return nil
 }
 
+// Reconfigure 配置文件变化时更新用户列表，无效配置被拒绝，旧用户列表继续生效
+func (p *AuthPlugin) Reconfigure(config []byte) error {
+	users, err := parseUsers(config)
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return errors.New("no users configured")
+	}
+	p.users.Store(&users)
+	p.log.Info("reconfigured", "users", len(users))
+	return nil
+}
+
+// parseUsers 解析配置中的用户列表
+func parseUsers(config []byte) (map[string]string, error) {
+	var cfg struct {
+		Users map[string]string `json:"users"`
+	}
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.Users, nil
+}
+
 // OnAuth 认证钩子
 func (p *AuthPlugin) OnAuth(ctx *pluginapi.AuthContext) (bool, error) {
 	// 示例：简单的用户名密码验证
-	expectedPass, exists := p.users[ctx.Username]
+	expectedPass, exists := (*p.users.Load())[ctx.Username]
 	if !exists {
-		fmt.Printf("[auth_plugin] User not found: %s\n", ctx.Username)
+		p.log.Warn("user not found", "username", ctx.Username, "ip", ctx.IP)
 		ctx.ThreatScore = 30 // 用户不存在，记录威胁分
 		return false, nil
 	}
 
 	if string(ctx.Password) != expectedPass {
-		fmt.Printf("[auth_plugin] Wrong password for user: %s\n", ctx.Username)
+		p.log.Warn("wrong password", "username", ctx.Username, "ip", ctx.IP)
 		ctx.ThreatScore = 50 // 密码错误，较高威胁分（累计达阈值自动拉黑）
 		return false, nil
 	}
 
-	fmt.Printf("[auth_plugin] Auth success: user=%s, client=%s, ip=%s\n",
-		ctx.Username, ctx.ClientID, ctx.IP)
+	p.log.Info("auth success", "username", ctx.Username, "client_id", ctx.ClientID, "ip", ctx.IP)
 	return true, nil
 }
 
@@ -94,7 +155,7 @@ func (p *AuthPlugin) OnAuth(ctx *pluginapi.AuthContext) (bool, error) {
 func (p *AuthPlugin) OnSubscribe(ctx *pluginapi.SubscribeContext) (bool, error) {
 	// 示例：禁止订阅 $SYS 主题（除非是 admin）
 	if strings.HasPrefix(ctx.Topic, "$SYS") && ctx.Username != "admin" {
-		fmt.Printf("[auth_plugin] Denied $SYS subscription for non-admin user: %s\n", ctx.Username)
+		p.log.Warn("denied $SYS subscription for non-admin user", "username", ctx.Username, "topic", ctx.Topic)
 		return false, nil
 	}
 	return true, nil
@@ -103,18 +164,16 @@ func (p *AuthPlugin) OnSubscribe(ctx *pluginapi.SubscribeContext) (bool, error)
 // OnPublish 发布钩子（异步通知）
 func (p *AuthPlugin) OnPublish(ctx *pluginapi.PublishContext) {
 	// 示例：记录所有发布消息（用于审计）
-	fmt.Printf("[auth_plugin] Publish: user=%s, topic=%s, qos=%d, size=%d\n",
-		ctx.Username, ctx.Topic, ctx.QoS, len(ctx.Payload))
+	p.log.Debug("publish", "username", ctx.Username, "topic", ctx.Topic, "qos", ctx.QoS, "size", len(ctx.Payload))
 }
 
 // OnDisconnect 断开钩子
 func (p *AuthPlugin) OnDisconnect(ctx *pluginapi.DisconnectContext) {
-	fmt.Printf("[auth_plugin] Disconnect: user=%s, client=%s, reason=%s\n",
-		ctx.Username, ctx.ClientID, ctx.Reason)
+	p.log.Info("disconnect", "username", ctx.Username, "client_id", ctx.ClientID, "reason", ctx.Reason)
 }
 
 // Close 关闭插件
 func (p *AuthPlugin) Close() error {
-	fmt.Println("[auth_plugin] Closing...")
+	p.log.Info("closing")
 	return nil
 }
@@ -2,21 +2,25 @@
 // AXMQ Plugin SDK - Logger Plugin Example
 //
 // 示例：消息日志插件
-// 演示如何实现 OnPublish 钩子，记录所有消息到外部系统
+// 演示如何实现 OnPublish 钩子，记录所有消息到外部系统；
+// 同时实现 BatchPublisher，由主程序攒批后一次写入多条
 //
-// 构建（Linux amd64）：go run ../../tools/build/main.go -dir . -output ./logger_plugin.so -goos linux -goarch amd64
-// 测试（Linux amd64 环境）：go run ../../runner/main.go -plugin ./logger_plugin.so
+// 构建（Linux amd64）：go run ../../tools/build -dir . -output ./logger_plugin.so -goos linux -goarch amd64
+// 测试（Linux amd64 环境）：go run ../../runner -plugin ./logger_plugin.so
 
 package main
 
 import (
+	_ "embed"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"runtime"
 	"sync"
 	"time"
 
+	"github.com/AXMQ-NET/axmq-plugin-sdk/metrics"
 	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
 )
 
@@ -25,14 +29,27 @@ type LoggerPlugin struct {
 	pluginapi.BasePlugin
 
 	// 配置
-	logPath   string
-	logFile   *os.File
-	mu        sync.Mutex
-	msgCount  int64
-	connCount int64
+	logPath string
+	logFile *os.File
+	mu      sync.Mutex
+	log     *slog.Logger
+
+	// 指标（由主程序统一导出）
+	msgCount    pluginapi.Counter
+	connCount   pluginapi.Counter
+	payloadSize pluginapi.Histogram
 }
 
-var _ pluginapi.Plugin = (*LoggerPlugin)(nil)
+var (
+	_ pluginapi.Plugin          = (*LoggerPlugin)(nil)
+	_ pluginapi.HostInitializer = (*LoggerPlugin)(nil)
+	_ pluginapi.BatchPublisher  = (*LoggerPlugin)(nil)
+)
+
+// configSchema 配置 Schema，主程序在 Init / Reconfigure 之前校验，构建工具同时写入 .meta.json
+//
+//go:embed config.schema.json
+var configSchema []byte
 
 // NewPlugin 插件工厂函数
 func NewPlugin() pluginapi.Plugin {
@@ -42,16 +59,52 @@ func NewPlugin() pluginapi.Plugin {
 // Info 返回插件元信息
 func (p *LoggerPlugin) Info() pluginapi.PluginMeta {
 	return pluginapi.PluginMeta{
-		Name:       "logger_plugin",
-		Version:    "1.0.0",
-		SDKVersion: pluginapi.SDKVersion,
-		GoVersion:  runtime.Version(),
-		BuildTime:  time.Now().Format(time.RFC3339),
+		Name:         "logger_plugin",
+		Version:      "1.0.0",
+		SDKVersion:   pluginapi.SDKVersion,
+		GoVersion:    runtime.Version(),
+		BuildTime:    time.Now().Format(time.RFC3339),
+		Description:  "将连接与消息记录到 JSON 日志文件",
+		Author:       "AXMQ Authors",
+		License:      "Apache-2.0",
+		Homepage:     "https://github.com/AXMQ-NET/axmq-plugin-sdk",
+		ConfigSchema: configSchema,
+		// 未实现 OnSubscribe，主程序不会为其分发订阅事件
+		Hooks: pluginapi.HookAuth | pluginapi.HookPublish | pluginapi.HookDisconnect,
+		// 实现了 OnPublishBatch：每 500 条或 100ms 写一次文件，超时针对整批
+		BatchSize:   500,
+		BatchLinger: 100 * time.Millisecond,
+		HookPolicies: pluginapi.HookPolicies{
+			pluginapi.HookPublish: {Timeout: time.Second},
+		},
 	}
 }
 
-// Init 初始化插件
+// Init 初始化插件（主程序不提供 HostAPI 时使用，日志输出到标准 slog，指标仅在本地统计）
 func (p *LoggerPlugin) Init(config []byte) error {
+	p.log = slog.Default().With(pluginapi.LogKeyPlugin, "logger_plugin")
+	return p.init(config, metrics.NewRegistry().ForPlugin("logger_plugin"))
+}
+
+// InitWithHost 初始化插件，日志与指标交由主程序统一处理
+func (p *LoggerPlugin) InitWithHost(host pluginapi.HostAPI, config []byte) error {
+	p.log = host.Logger()
+	return p.init(config, host.Metrics())
+}
+
+func (p *LoggerPlugin) init(config []byte, m pluginapi.Metrics) error {
+	var err error
+	if p.msgCount, err = m.Counter("messages_total", "Messages logged by logger_plugin", nil); err != nil {
+		return err
+	}
+	if p.connCount, err = m.Counter("connections_total", "Connections logged by logger_plugin", nil); err != nil {
+		return err
+	}
+	payloadBuckets := []float64{64, 256, 1024, 4096, 16384, 65536}
+	if p.payloadSize, err = m.Histogram("payload_bytes", "Payload size of logged messages", payloadBuckets, nil); err != nil {
+		return err
+	}
+
 	// 默认日志路径
 	p.logPath = "/tmp/axmq_messages.log"
 
@@ -60,27 +113,27 @@ func (p *LoggerPlugin) Init(config []byte) error {
 		var cfg struct {
 			LogPath string `json:"log_path"`
 		}
-		if err := json.Unmarshal(config, &cfg); err == nil && cfg.LogPath != "" {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+		if cfg.LogPath != "" {
 			p.logPath = cfg.LogPath
 		}
 	}
 
 	// 打开日志文件
-	var err error
 	p.logFile, err = os.OpenFile(p.logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
 	}
 
-	fmt.Printf("[logger_plugin] Logging to: %s\n", p.logPath)
+	p.log.Info("logging to file", "path", p.logPath)
 	return nil
 }
 
 // OnAuth 认证钩子 - 记录连接
 func (p *LoggerPlugin) OnAuth(ctx *pluginapi.AuthContext) (bool, error) {
-	p.mu.Lock()
-	p.connCount++
-	p.mu.Unlock()
+	p.connCount.Inc()
 
 	p.writeLog("CONNECT", map[string]interface{}{
 		"client_id": ctx.ClientID,
@@ -93,9 +146,11 @@ func (p *LoggerPlugin) OnAuth(ctx *pluginapi.AuthContext) (bool, error) {
 
 // OnPublish 发布钩子 - 记录消息（异步通知，不阻塞消息分发）
 func (p *LoggerPlugin) OnPublish(ctx *pluginapi.PublishContext) {
+	p.payloadSize.Observe(float64(len(ctx.Payload)))
+
 	p.mu.Lock()
-	p.msgCount++
-	count := p.msgCount
+	p.msgCount.Inc()
+	count := int64(p.msgCount.Value())
 	p.mu.Unlock()
 
 	p.writeLog("PUBLISH", map[string]interface{}{
@@ -109,6 +164,39 @@ func (p *LoggerPlugin) OnPublish(ctx *pluginapi.PublishContext) {
 	})
 }
 
+// OnPublishBatch 批量发布钩子 - 一次写入整批消息（实现后主程序不再调用 OnPublish）
+func (p *LoggerPlugin) OnPublishBatch(batch []pluginapi.PublishContext) {
+	now := time.Now().Format(time.RFC3339Nano)
+	var buf []byte
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range batch {
+		ctx := &batch[i]
+		p.payloadSize.Observe(float64(len(ctx.Payload)))
+		p.msgCount.Inc()
+
+		line, err := json.Marshal(map[string]interface{}{
+			"time":      now,
+			"event":     "PUBLISH",
+			"seq":       int64(p.msgCount.Value()),
+			"client_id": ctx.ClientID,
+			"username":  ctx.Username,
+			"topic":     ctx.Topic,
+			"qos":       ctx.QoS,
+			"retain":    ctx.Retain,
+			"size":      len(ctx.Payload),
+		})
+		if err != nil {
+			continue
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	if p.logFile != nil {
+		p.logFile.Write(buf)
+	}
+}
+
 // OnDisconnect 断开钩子 - 记录断开
 func (p *LoggerPlugin) OnDisconnect(ctx *pluginapi.DisconnectContext) {
 	p.writeLog("DISCONNECT", map[string]interface{}{
@@ -147,8 +235,7 @@ func (p *LoggerPlugin) Close() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	fmt.Printf("[logger_plugin] Closing. Total messages: %d, connections: %d\n",
-		p.msgCount, p.connCount)
+	p.log.Info("closing", "messages", p.msgCount.Value(), "connections", p.connCount.Value())
 
 	if p.logFile != nil {
 		p.logFile.Close()
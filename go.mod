@@ -1,3 +1,5 @@
 module github.com/AXMQ-NET/axmq-plugin-sdk
 
 go 1.25.6
+
+require gopkg.in/yaml.v3 v3.0.1
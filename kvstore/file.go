@@ -0,0 +1,240 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - File-backed Key-Value Store
+//
+// pluginapi.KVStore 的参考实现，每个命名空间（插件）对应目录下的一个 JSON 文件
+// 每次写入都会原子替换文件（写临时文件后 rename），进程崩溃不会损坏数据
+//
+// 使用方法：
+//   fs, err := kvstore.Open("./data/plugin_store")
+//   store, err := fs.Namespace("my_plugin")   // 传给插件的 pluginapi.KVStore
+
+package kvstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// ErrInvalidNamespace 命名空间名称非法
+var ErrInvalidNamespace = errors.New("invalid store namespace")
+
+// FileStore 基于文件的存储，管理多个命名空间
+type FileStore struct {
+	dir    string
+	mu     sync.Mutex
+	spaces map[string]*namespace
+}
+
+// Open 打开（必要时创建）存储目录
+func Open(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	return &FileStore{dir: dir, spaces: make(map[string]*namespace)}, nil
+}
+
+// Dir 返回存储目录
+func (s *FileStore) Dir() string {
+	return s.dir
+}
+
+// Namespace 返回指定命名空间的 KVStore，同名返回同一实例
+// 插件热加载后使用相同名称即可读到之前的数据
+func (s *FileStore) Namespace(name string) (pluginapi.KVStore, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNamespace, name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ns, ok := s.spaces[name]; ok {
+		return ns, nil
+	}
+
+	ns := &namespace{
+		path: filepath.Join(s.dir, name+".json"),
+		data: make(map[string]entry),
+	}
+	if err := ns.load(); err != nil {
+		return nil, err
+	}
+	s.spaces[name] = ns
+	return ns, nil
+}
+
+// entry 存储的值
+type entry struct {
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// namespace 单个命名空间
+type namespace struct {
+	path string
+	mu   sync.Mutex
+	data map[string]entry
+}
+
+var _ pluginapi.KVStore = (*namespace)(nil)
+
+func (n *namespace) load() error {
+	raw, err := os.ReadFile(n.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read store file: %w", err)
+	}
+	if err := json.Unmarshal(raw, &n.data); err != nil {
+		return fmt.Errorf("failed to parse store file %s: %w", n.path, err)
+	}
+	return nil
+}
+
+// persist 原子写入文件（调用方持有锁）
+func (n *namespace) persist() error {
+	now := time.Now()
+	for k, e := range n.data {
+		if e.expired(now) {
+			delete(n.data, k)
+		}
+	}
+
+	raw, err := json.Marshal(n.data)
+	if err != nil {
+		return err
+	}
+	tmp := n.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to sync store file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	return os.Rename(tmp, n.path)
+}
+
+// apply 修改单个键并写入文件，写入失败时回滚内存中的修改，保持内存与文件一致（调用方持有锁）
+// e 为 nil 表示删除
+func (n *namespace) apply(key string, e *entry) error {
+	prev, existed := n.data[key]
+	if e == nil {
+		delete(n.data, key)
+	} else {
+		n.data[key] = *e
+	}
+	if err := n.persist(); err != nil {
+		if existed {
+			n.data[key] = prev
+		} else {
+			delete(n.data, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func newEntry(value []byte, ttl time.Duration) entry {
+	e := entry{Value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.ExpiresAt = time.Now().Add(ttl)
+	}
+	return e
+}
+
+func (n *namespace) Get(key string) ([]byte, bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	e, ok := n.data[key]
+	if !ok || e.expired(time.Now()) {
+		return nil, false, nil
+	}
+	return append([]byte(nil), e.Value...), true, nil
+}
+
+func (n *namespace) Set(key string, value []byte, ttl time.Duration) error {
+	if err := pluginapi.ValidateKV(key, value); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	e := newEntry(value, ttl)
+	return n.apply(key, &e)
+}
+
+func (n *namespace) Delete(key string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.data[key]; !ok {
+		return nil
+	}
+	return n.apply(key, nil)
+}
+
+func (n *namespace) Scan(prefix string) ([]pluginapi.KV, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := time.Now()
+	var kvs []pluginapi.KV
+	for k, e := range n.data {
+		if !strings.HasPrefix(k, prefix) || e.expired(now) {
+			continue
+		}
+		kvs = append(kvs, pluginapi.KV{Key: k, Value: append([]byte(nil), e.Value...), ExpiresAt: e.ExpiresAt})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs, nil
+}
+
+func (n *namespace) CompareAndSwap(key string, old, new []byte, ttl time.Duration) (bool, error) {
+	if err := pluginapi.ValidateKV(key, new); err != nil {
+		return false, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	e, exists := n.data[key]
+	if exists && e.expired(time.Now()) {
+		exists = false
+	}
+	if old == nil {
+		if exists {
+			return false, nil
+		}
+	} else if !exists || !bytes.Equal(e.Value, old) {
+		return false, nil
+	}
+
+	var next *entry
+	if new != nil {
+		e := newEntry(new, ttl)
+		next = &e
+	}
+	if err := n.apply(key, next); err != nil {
+		return false, err
+	}
+	return true, nil
+}
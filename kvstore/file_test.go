@@ -0,0 +1,211 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - File-backed Key-Value Store Tests
+
+package kvstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// openNamespace 在临时目录中打开存储，返回命名空间 "p"
+func openNamespace(t *testing.T, dir string) pluginapi.KVStore {
+	t.Helper()
+	fs, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := fs.Namespace("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// mustGet 读取键值，键不存在时返回空字符串
+func mustGet(t *testing.T, store pluginapi.KVStore, key string) string {
+	t.Helper()
+	v, ok, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	if !ok {
+		return ""
+	}
+	return string(v)
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string // 为空表示键不存在
+		old     []byte
+		new     []byte
+		swapped bool
+		want    string // 操作后的值，为空表示键不存在
+	}{
+		{"create when absent", "", nil, []byte("1"), true, "1"},
+		{"create conflicts with existing", "1", nil, []byte("2"), false, "1"},
+		{"swap on match", "1", []byte("1"), []byte("2"), true, "2"},
+		{"conflict on mismatch", "1", []byte("0"), []byte("2"), false, "1"},
+		{"conflict when absent", "", []byte("1"), []byte("2"), false, ""},
+		{"delete on match", "1", []byte("1"), nil, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := openNamespace(t, t.TempDir())
+			if tt.initial != "" {
+				if err := store.Set("k", []byte(tt.initial), 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			swapped, err := store.CompareAndSwap("k", tt.old, tt.new, 0)
+			if err != nil || swapped != tt.swapped {
+				t.Fatalf("CompareAndSwap = %v, %v; want %v, nil", swapped, err, tt.swapped)
+			}
+			if got := mustGet(t, store, "k"); got != tt.want {
+				t.Fatalf("value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTL(t *testing.T) {
+	store := openNamespace(t, t.TempDir())
+	if err := store.Set("session/a", []byte("a"), 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("session/b", []byte("b"), 0); err != nil {
+		t.Fatal(err)
+	}
+	kvs, err := store.Scan("session/")
+	if err != nil || len(kvs) != 2 || kvs[0].ExpiresAt.IsZero() || !kvs[1].ExpiresAt.IsZero() {
+		t.Fatalf("Scan before expiry = %v, %v", kvs, err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if got := mustGet(t, store, "session/a"); got != "" {
+		t.Fatalf("expired key returned %q", got)
+	}
+	kvs, err = store.Scan("session/")
+	if err != nil || len(kvs) != 1 || kvs[0].Key != "session/b" {
+		t.Fatalf("Scan after expiry = %v, %v; want only session/b", kvs, err)
+	}
+
+	// 过期的键视为不存在，old 为 nil 的 CompareAndSwap 可以重新创建
+	if swapped, err := store.CompareAndSwap("session/a", nil, []byte("a2"), 0); !swapped || err != nil {
+		t.Fatalf("CompareAndSwap on expired key = %v, %v; want true, nil", swapped, err)
+	}
+}
+
+func TestScanPrefix(t *testing.T) {
+	store := openNamespace(t, t.TempDir())
+	for _, k := range []string{"b/2", "a/1", "b/1", "c"} {
+		if err := store.Set(k, []byte(k), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kvs, err := store.Scan("b/")
+	if err != nil || len(kvs) != 2 || kvs[0].Key != "b/1" || kvs[1].Key != "b/2" {
+		t.Fatalf("Scan(b/) = %v, %v; want b/1, b/2", kvs, err)
+	}
+	if err := store.Delete("b/1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("missing"); err != nil {
+		t.Fatalf("Delete of a missing key: %v", err)
+	}
+	if kvs, _ := store.Scan(""); len(kvs) != 3 {
+		t.Fatalf("Scan(\"\") returned %d keys, want 3", len(kvs))
+	}
+}
+
+// TestPersistFailureRollsBack 写入文件失败时内存中的值保持不变
+func TestPersistFailureRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	store := openNamespace(t, dir)
+	if err := store.Set("k", []byte("1"), 0); err != nil {
+		t.Fatal(err)
+	}
+	// 临时文件的位置被目录占用，之后的写入都会失败
+	if err := os.Mkdir(filepath.Join(dir, "p.json.tmp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Set("k", []byte("2"), 0); err == nil {
+		t.Fatal("Set succeeded although the store file cannot be written")
+	}
+	if err := store.Set("new", []byte("x"), 0); err == nil {
+		t.Fatal("Set of a new key succeeded although the store file cannot be written")
+	}
+	if swapped, err := store.CompareAndSwap("k", []byte("1"), []byte("3"), 0); swapped || err == nil {
+		t.Fatalf("CompareAndSwap = %v, %v; want false and an error", swapped, err)
+	}
+	if err := store.Delete("k"); err == nil {
+		t.Fatal("Delete succeeded although the store file cannot be written")
+	}
+	if got := mustGet(t, store, "k"); got != "1" {
+		t.Fatalf("value = %q after failed writes, want 1", got)
+	}
+	if got := mustGet(t, store, "new"); got != "" {
+		t.Fatalf("new key visible after failed Set: %q", got)
+	}
+}
+
+// TestReopen 重新打开存储目录（如热加载或重启后）读到之前写入的数据
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	store := openNamespace(t, dir)
+	if err := store.Set("keep", []byte("v"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("short", []byte("v"), 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("gone", []byte("v"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("gone"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	reopened := openNamespace(t, dir)
+	if got := mustGet(t, reopened, "keep"); got != "v" {
+		t.Fatalf("keep = %q after reopen, want v", got)
+	}
+	for _, k := range []string{"short", "gone"} {
+		if got := mustGet(t, reopened, k); got != "" {
+			t.Fatalf("%s = %q after reopen, want absent", k, got)
+		}
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	fs, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`} {
+		if _, err := fs.Namespace(name); !errors.Is(err, ErrInvalidNamespace) {
+			t.Errorf("Namespace(%q) err = %v, want ErrInvalidNamespace", name, err)
+		}
+	}
+	a, _ := fs.Namespace("a")
+	again, _ := fs.Namespace("a")
+	if a != again {
+		t.Fatal("Namespace returned different instances for the same name")
+	}
+	b, _ := fs.Namespace("b")
+	if err := a.Set("k", []byte("a"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustGet(t, b, "k"); got != "" {
+		t.Fatalf("namespace b sees %q from namespace a", got)
+	}
+}
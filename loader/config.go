@@ -0,0 +1,93 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader (Config)
+
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// ConfigJSON 将插件配置转换为 JSON，主程序校验后传给插件的 Init / Reconfigure，插件总是收到 JSON
+// 以 '{' 开头的配置视为 JSON 原样返回，其他按 YAML 解析（与 pluginapi.LogLevelFromConfig 的判断一致）；
+// 空配置返回 {}
+func ConfigJSON(config []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(config)
+	if len(trimmed) == 0 {
+		return []byte("{}"), nil
+	}
+	if trimmed[0] == '{' {
+		return trimmed, nil
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(trimmed, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", pluginapi.ErrInvalidConfig, err)
+	}
+	if doc == nil {
+		return []byte("{}"), nil // 只有注释的 YAML
+	}
+	doc, err := jsonValue("", doc)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pluginapi.ErrInvalidConfig, err)
+	}
+	return data, nil
+}
+
+// jsonValue 将 YAML 解码结果转换为可编码为 JSON 的值
+// 映射的键转换为字符串，时间戳按 RFC 3339 输出；非字符串键（如 1: x）按 YAML 的写法保留
+func jsonValue(path string, v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			item, err := jsonValue(path+"/"+k, item)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = item
+		}
+		return v, nil
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			key := fmt.Sprint(k)
+			item, err := jsonValue(path+"/"+key, item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = item
+		}
+		return m, nil
+	case []any:
+		for i, item := range v {
+			item, err := jsonValue(fmt.Sprintf("%s/%d", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = item
+		}
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			if path == "" {
+				path = "/"
+			}
+			return nil, fmt.Errorf("%w: %s: %v cannot be represented in JSON", pluginapi.ErrInvalidConfig, path, v)
+		}
+		return v, nil
+	}
+	return v, nil
+}
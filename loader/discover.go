@@ -0,0 +1,91 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader (Directory Discovery)
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// ConfigExts 插件配置文件的扩展名，按优先级排列
+var ConfigExts = []string{".yml", ".yaml", ".json"}
+
+// Discover 按主程序的规则扫描插件目录：
+//   - 每个 <name>.so 为一个插件，<name>.yml（或 .yaml / .json）为默认实例的配置
+//   - <name>@<instance>.yml 各对应一个命名实例；存在命名实例且没有默认配置时不加载默认实例
+//   - 同一实例有多个扩展名的配置时按 ConfigExts 的顺序取第一个
+//
+// 结果按文件名排序；找不到对应 .so 的配置文件与 .meta.json 被忽略
+func Discover(dir string) ([]Spec, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make(map[string]bool)
+	configs := make(map[string]map[string]string) // .so 文件名 -> 实例名 -> 配置文件
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if strings.HasSuffix(name, MetaSuffix) {
+			continue
+		}
+		ext := filepath.Ext(name)
+		if ext == ".so" {
+			plugins[strings.TrimSuffix(name, ext)] = true
+			continue
+		}
+		rank := configRank(ext)
+		if rank < 0 {
+			continue
+		}
+		base, instance, err := pluginapi.ConfigFileInstance(name)
+		if err != nil {
+			// 实例名非法时仍返回该实例，由 Open 以 ErrInvalidInstanceName 拒绝
+			base, instance, _ = strings.Cut(strings.TrimSuffix(name, ext), pluginapi.InstanceSeparator)
+		}
+		if configs[base] == nil {
+			configs[base] = make(map[string]string)
+		}
+		if prev, ok := configs[base][instance]; !ok || rank < configRank(filepath.Ext(prev)) {
+			configs[base][instance] = name
+		}
+	}
+
+	var specs []Spec
+	for base := range plugins {
+		path := filepath.Join(dir, base+".so")
+		instances := configs[base]
+		if len(instances) == 0 {
+			specs = append(specs, Spec{Path: path})
+			continue
+		}
+		for instance, config := range instances {
+			specs = append(specs, Spec{Path: path, ConfigPath: filepath.Join(dir, config), Instance: instance})
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].Path != specs[j].Path {
+			return specs[i].Path < specs[j].Path
+		}
+		return specs[i].Instance < specs[j].Instance
+	})
+	return specs, nil
+}
+
+// configRank 返回扩展名在 ConfigExts 中的位置，不是配置文件时返回 -1
+func configRank(ext string) int {
+	for i, e := range ConfigExts {
+		if e == ext {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader (Directory Discovery) Tests
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// touch 在 dir 中创建空文件
+func touch(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []Spec // Path 与 ConfigPath 为相对 dir 的文件名
+	}{
+		{
+			name:  "no config",
+			files: []string{"a.so"},
+			want:  []Spec{{Path: "a.so"}},
+		},
+		{
+			name:  "default config",
+			files: []string{"a.so", "a.yml"},
+			want:  []Spec{{Path: "a.so", ConfigPath: "a.yml"}},
+		},
+		{
+			name:  "named instances only",
+			files: []string{"webhook.so", "webhook@billing.yml", "webhook@audit.json"},
+			want: []Spec{
+				{Path: "webhook.so", ConfigPath: "webhook@audit.json", Instance: "audit"},
+				{Path: "webhook.so", ConfigPath: "webhook@billing.yml", Instance: "billing"},
+			},
+		},
+		{
+			name:  "default and named instances",
+			files: []string{"webhook.so", "webhook.yaml", "webhook@billing.yml"},
+			want: []Spec{
+				{Path: "webhook.so", ConfigPath: "webhook.yaml"},
+				{Path: "webhook.so", ConfigPath: "webhook@billing.yml", Instance: "billing"},
+			},
+		},
+		{
+			name:  "extension priority",
+			files: []string{"a.so", "a.json", "a.yaml", "a.yml", "b.so", "b.json", "b.yaml"},
+			want: []Spec{
+				{Path: "a.so", ConfigPath: "a.yml"},
+				{Path: "b.so", ConfigPath: "b.yaml"},
+			},
+		},
+		{
+			name:  "meta files and orphan configs ignored",
+			files: []string{"a.so", "a.so.meta.json", "orphan.yml", "notes.txt"},
+			want:  []Spec{{Path: "a.so"}},
+		},
+		{
+			name:  "invalid instance name kept for Open to refuse",
+			files: []string{"a.so", "a@bad!.yml"},
+			want:  []Spec{{Path: "a.so", ConfigPath: "a@bad!.yml", Instance: "bad!"}},
+		},
+		{
+			name:  "sorted by path then instance",
+			files: []string{"b.so", "a.so", "a@z.yml", "a@m.yml"},
+			want: []Spec{
+				{Path: "a.so", ConfigPath: "a@m.yml", Instance: "m"},
+				{Path: "a.so", ConfigPath: "a@z.yml", Instance: "z"},
+				{Path: "b.so"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			touch(t, dir, tt.files...)
+			if err := os.Mkdir(filepath.Join(dir, "sub.so"), 0o755); err != nil {
+				t.Fatal(err) // 目录即使以 .so 结尾也被忽略
+			}
+
+			got, err := Discover(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := make([]Spec, len(tt.want))
+			for i, s := range tt.want {
+				want[i] = Spec{Path: filepath.Join(dir, s.Path), Instance: s.Instance}
+				if s.ConfigPath != "" {
+					want[i].ConfigPath = filepath.Join(dir, s.ConfigPath)
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("Discover =\n  %v\nwant\n  %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDiscoverMissingDir(t *testing.T) {
+	if _, err := Discover(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader (Plugin Sets)
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// Load 按主程序的流程加载一组插件实例（步骤 1~8）
+// 返回可加载的插件（按执行顺序）与被拒绝插件的错误（errors.Join 的 *LoadError，可用 Errors 展开）；
+// 被拒绝的插件不影响其他插件
+func Load(specs []Spec, opts Options) ([]*Plugin, error) {
+	var refused []error
+	set := newPluginSet(len(specs))
+	for _, spec := range specs {
+		p, err := Open(spec, opts)
+		if err == nil {
+			err = set.add(p)
+		}
+		if err != nil {
+			refused = append(refused, err)
+		}
+	}
+
+	// 依赖与执行顺序按插件计算，插件被拒绝时其所有实例一并拒绝
+	sorted, reasons := order(set.all)
+	return sorted, errors.Join(append(refused, reasons...)...)
+}
+
+// pluginSet 已接受的插件实例（步骤 6）
+type pluginSet struct {
+	all    []*Plugin
+	byName map[string]*Plugin // 完整名称 -> 实例
+	first  map[string]*Plugin // 插件名 -> 第一个实例
+}
+
+func newPluginSet(n int) *pluginSet {
+	return &pluginSet{byName: make(map[string]*Plugin, n), first: make(map[string]*Plugin, n)}
+}
+
+// add 接受一个实例；同名插件来自不同的 .so（按绝对路径比较）或完整名称重复时返回 *LoadError
+func (s *pluginSet) add(p *Plugin) error {
+	if prev, ok := s.first[p.Meta.Name]; ok && absPath(prev.Path) != absPath(p.Path) {
+		return &LoadError{Path: p.Path, Name: p.Name(),
+			Err: fmt.Errorf("%w: %s (also in %s)", pluginapi.ErrPluginAlreadyExist, p.Meta.Name, prev.Path)}
+	}
+	if prev, ok := s.byName[p.Name()]; ok {
+		prevConfig, config := prev.ConfigPath, p.ConfigPath
+		if prevConfig == "" {
+			prevConfig = "none"
+		}
+		if config == "" {
+			config = "none"
+		}
+		return &LoadError{Path: p.Path, Name: p.Name(),
+			Err: fmt.Errorf("%w: %s (config %s and %s); name each instance with a config file %s@<instance>",
+				pluginapi.ErrPluginAlreadyExist, p.Name(), prevConfig, config, p.Meta.Name)}
+	}
+	s.byName[p.Name()] = p
+	if _, ok := s.first[p.Meta.Name]; !ok {
+		s.first[p.Meta.Name] = p
+	}
+	s.all = append(s.all, p)
+	return nil
+}
+
+// order 解析依赖（步骤 7）并计算执行顺序（步骤 8），返回按执行顺序展开的实例与被拒绝插件的 *LoadError
+// After 成环时只拒绝环中的插件，依赖它们的插件随之被拒绝，其余插件照常排序
+func order(all []*Plugin) ([]*Plugin, []error) {
+	var refused []error
+	for {
+		loadable, reasons := resolve(all)
+		refused = append(refused, reasons...)
+		all = Expand(loadable, all)
+
+		sorted, err := pluginapi.SortPlugins(loadable)
+		var cycle *pluginapi.OrderCycleError
+		if !errors.As(err, &cycle) {
+			if err != nil { // 名称已在 pluginSet 中去重，不会出现其他错误
+				return nil, append(refused, err)
+			}
+			return Expand(sorted, all), refused
+		}
+
+		cyclic := make(map[string]bool, len(cycle.Plugins))
+		for _, name := range cycle.Plugins {
+			cyclic[name] = true
+		}
+		kept := make([]*Plugin, 0, len(all))
+		reported := make(map[string]bool, len(cyclic))
+		for _, p := range all {
+			if !cyclic[p.Meta.Name] {
+				kept = append(kept, p)
+				continue
+			}
+			if !reported[p.Meta.Name] { // 路径取该插件的第一个实例
+				reported[p.Meta.Name] = true
+				refused = append(refused, &LoadError{Path: p.Path, Name: p.Meta.Name, Err: cycle})
+			}
+		}
+		all = kept
+	}
+}
+
+// resolve 解析插件依赖（步骤 7），返回可加载的插件与每个被拒绝插件的 *LoadError（路径取该插件的第一个实例）
+func resolve(all []*Plugin) ([]pluginapi.PluginMeta, []error) {
+	metas := Metas(all)
+	loadable, err := pluginapi.ResolveDependencies(metas)
+	if len(loadable) == len(metas) {
+		return loadable, nil
+	}
+
+	ok := make(map[string]bool, len(loadable))
+	for _, m := range loadable {
+		ok[m.Name] = true
+	}
+	reasons := make(map[string]error) // 插件名 -> 拒绝原因
+	for _, e := range Errors(err) {
+		var de *pluginapi.DependencyError
+		if errors.As(e, &de) {
+			reasons[de.Plugin] = de.Err
+		}
+	}
+	var refused []error
+	for _, m := range metas {
+		if ok[m.Name] {
+			continue
+		}
+		reason := reasons[m.Name]
+		if reason == nil {
+			reason = pluginapi.ErrMissingDependency
+		}
+		var path string
+		for _, p := range all {
+			if p.Meta.Name == m.Name {
+				path = p.Path
+				break
+			}
+		}
+		refused = append(refused, &LoadError{Path: path, Name: m.Name, Err: reason})
+	}
+	return loadable, refused
+}
+
+// InitOrder 返回依赖优先的初始化顺序，关闭时逆序；同一插件的实例相邻
+func InitOrder(plugins []*Plugin) []*Plugin {
+	metas := Metas(plugins)
+	ordered, _ := pluginapi.ResolveDependencies(metas)
+	if len(ordered) != len(metas) {
+		return plugins
+	}
+	return Expand(ordered, plugins)
+}
+
+// Metas 返回去重后的插件元信息（同一插件的多个实例取第一个），供排序与依赖解析使用
+func Metas(plugins []*Plugin) []pluginapi.PluginMeta {
+	seen := make(map[string]bool, len(plugins))
+	metas := make([]pluginapi.PluginMeta, 0, len(plugins))
+	for _, p := range plugins {
+		if !seen[p.Meta.Name] {
+			seen[p.Meta.Name] = true
+			metas = append(metas, p.Meta)
+		}
+	}
+	return metas
+}
+
+// Expand 按插件顺序展开实例，同一插件的实例相邻并保持原有顺序，不在 order 中的插件被丢弃
+func Expand(order []pluginapi.PluginMeta, plugins []*Plugin) []*Plugin {
+	out := make([]*Plugin, 0, len(plugins))
+	for _, m := range order {
+		for _, p := range plugins {
+			if p.Meta.Name == m.Name {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+// absPath 返回用于比较的绝对路径，"./a.so" 与 "a.so" 视为同一文件；无法取得工作目录时退回 filepath.Clean
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// Errors 展开 errors.Join 的结果，如 Load 返回的错误展开为每个被拒绝插件的 *LoadError
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader (Plugin Sets) Tests
+
+package loader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// fake 返回已打开的插件实例（不经过 plugin.Open）
+func fake(path, instance string, meta pluginapi.PluginMeta) *Plugin {
+	return &Plugin{Spec: Spec{Path: path, Instance: instance}, Meta: meta}
+}
+
+func TestPluginSetAdd(t *testing.T) {
+	a := pluginapi.PluginMeta{Name: "a"}
+	tests := []struct {
+		name    string
+		plugins []*Plugin
+		refused []int // 被拒绝的下标
+	}{
+		{"instances of one file", []*Plugin{fake("a.so", "", a), fake("a.so", "x", a)}, nil},
+		{"same file, different spelling", []*Plugin{fake("a.so", "x", a), fake("./a.so", "y", a), fake("sub/../a.so", "z", a)}, nil},
+		{"same name in another file", []*Plugin{fake("a.so", "", a), fake("other/a.so", "x", a)}, []int{1}},
+		{"duplicate instance", []*Plugin{fake("a.so", "x", a), fake("./a.so", "x", a)}, []int{1}},
+		{"duplicate default instance", []*Plugin{fake("a.so", "", a), fake("a.so", "", a)}, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newPluginSet(len(tt.plugins))
+			var refused []int
+			for i, p := range tt.plugins {
+				if err := set.add(p); err != nil {
+					if !errors.Is(err, pluginapi.ErrPluginAlreadyExist) {
+						t.Fatalf("add(%d): %v, want ErrPluginAlreadyExist", i, err)
+					}
+					refused = append(refused, i)
+				}
+			}
+			if !reflect.DeepEqual(refused, tt.refused) {
+				t.Fatalf("refused %v, want %v", refused, tt.refused)
+			}
+			if len(set.all) != len(tt.plugins)-len(tt.refused) {
+				t.Fatalf("accepted %d plugins, want %d", len(set.all), len(tt.plugins)-len(tt.refused))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dep := func(name string) []pluginapi.Dependency { return []pluginapi.Dependency{{Name: name}} }
+	tests := []struct {
+		name     string
+		plugins  []*Plugin
+		loadable []string
+		refused  map[string]error // 插件名 -> 原因
+	}{
+		{
+			name:     "all loadable",
+			plugins:  []*Plugin{fake("b.so", "", pluginapi.PluginMeta{Name: "b", Dependencies: dep("a")}), fake("a.so", "", pluginapi.PluginMeta{Name: "a"})},
+			loadable: []string{"a", "b"},
+		},
+		{
+			name: "refusal cascades",
+			plugins: []*Plugin{
+				fake("a.so", "", pluginapi.PluginMeta{Name: "a", Dependencies: dep("b")}),
+				fake("b.so", "x", pluginapi.PluginMeta{Name: "b", Dependencies: dep("c")}),
+				fake("b.so", "y", pluginapi.PluginMeta{Name: "b", Dependencies: dep("c")}),
+				fake("c.so", "", pluginapi.PluginMeta{Name: "c", Dependencies: dep("missing")}),
+				fake("d.so", "", pluginapi.PluginMeta{Name: "d"}),
+			},
+			loadable: []string{"d"},
+			refused:  map[string]error{"a": pluginapi.ErrMissingDependency, "b": pluginapi.ErrMissingDependency, "c": pluginapi.ErrMissingDependency},
+		},
+		{
+			name: "cycle and its dependents",
+			plugins: []*Plugin{
+				fake("user.so", "", pluginapi.PluginMeta{Name: "user", Dependencies: dep("x")}),
+				fake("x.so", "", pluginapi.PluginMeta{Name: "x", Dependencies: dep("y")}),
+				fake("y.so", "", pluginapi.PluginMeta{Name: "y", Dependencies: dep("x")}),
+				fake("ok.so", "", pluginapi.PluginMeta{Name: "ok"}),
+			},
+			loadable: []string{"ok"},
+			refused:  map[string]error{"user": pluginapi.ErrDependencyCycle, "x": pluginapi.ErrDependencyCycle, "y": pluginapi.ErrDependencyCycle},
+		},
+		{
+			name: "reasons keyed by name, not position",
+			plugins: []*Plugin{
+				fake("z.so", "", pluginapi.PluginMeta{Name: "z", Dependencies: dep("missing")}),
+				fake("m.so", "", pluginapi.PluginMeta{Name: "m", Dependencies: dep("n")}),
+				fake("n.so", "", pluginapi.PluginMeta{Name: "n", Dependencies: dep("m")}),
+			},
+			refused: map[string]error{"z": pluginapi.ErrMissingDependency, "m": pluginapi.ErrDependencyCycle, "n": pluginapi.ErrDependencyCycle},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadable, refused := resolve(tt.plugins)
+			var names []string
+			for _, m := range loadable {
+				names = append(names, m.Name)
+			}
+			if !reflect.DeepEqual(names, tt.loadable) {
+				t.Fatalf("loadable = %v, want %v", names, tt.loadable)
+			}
+			if len(refused) != len(tt.refused) {
+				t.Fatalf("got %d refusals (%v), want %d", len(refused), refused, len(tt.refused))
+			}
+			for _, err := range refused {
+				var le *LoadError
+				if !errors.As(err, &le) {
+					t.Fatalf("%v is not a *LoadError", err)
+				}
+				want, ok := tt.refused[le.Name]
+				if !ok || !errors.Is(err, want) {
+					t.Errorf("%s refused with %v, want %v", le.Name, err, want)
+				}
+				if le.Path != le.Name+".so" {
+					t.Errorf("%s refused with path %s, want %s.so", le.Name, le.Path, le.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderRefusesOnlyCycle(t *testing.T) {
+	dep := func(name string) []pluginapi.Dependency { return []pluginapi.Dependency{{Name: name}} }
+	plugins := []*Plugin{
+		fake("ok.so", "", pluginapi.PluginMeta{Name: "ok", Priority: 1}),
+		fake("a.so", "x", pluginapi.PluginMeta{Name: "a", After: []string{"b"}}),
+		fake("a.so", "y", pluginapi.PluginMeta{Name: "a", After: []string{"b"}}),
+		fake("b.so", "", pluginapi.PluginMeta{Name: "b", After: []string{"a"}}),
+		fake("later.so", "", pluginapi.PluginMeta{Name: "later", After: []string{"a", "ok"}}),
+		fake("user.so", "", pluginapi.PluginMeta{Name: "user", Dependencies: dep("b")}),
+	}
+	sorted, refused := order(plugins)
+
+	var names []string
+	for _, p := range sorted {
+		names = append(names, p.Name())
+	}
+	if want := []string{"ok", "later"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("loaded %v, want %v", names, want)
+	}
+	want := map[string]error{"a": pluginapi.ErrOrderCycle, "b": pluginapi.ErrOrderCycle, "user": pluginapi.ErrMissingDependency}
+	if len(refused) != len(want) {
+		t.Fatalf("got %d refusals (%v), want %d", len(refused), refused, len(want))
+	}
+	for _, err := range refused {
+		var le *LoadError
+		if !errors.As(err, &le) || !errors.Is(err, want[le.Name]) {
+			t.Errorf("refusal %v, want %v", err, want[le.Name])
+		}
+		if le != nil && le.Path != le.Name+".so" {
+			t.Errorf("%s refused with path %s, want %s.so", le.Name, le.Path, le.Name)
+		}
+	}
+}
+
+func TestLoadRefusesUnopenablePlugins(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "garbage.so"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	specs := []Spec{{Path: filepath.Join(dir, "missing.so")}, {Path: filepath.Join(dir, "garbage.so")}}
+
+	plugins, err := Load(specs, Options{AllowMissingMeta: true})
+	if len(plugins) != 0 {
+		t.Fatalf("loaded %d plugins, want 0", len(plugins))
+	}
+	refused := Errors(err)
+	if len(refused) != 2 || !errors.Is(refused[0], pluginapi.ErrPluginNotFound) || !errors.Is(refused[1], pluginapi.ErrPluginOpenFailed) {
+		t.Fatalf("Load err = %v, want ErrPluginNotFound and ErrPluginOpenFailed", err)
+	}
+}
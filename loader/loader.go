@@ -0,0 +1,209 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader
+//
+// 主程序加载插件的完整流程，主程序、本地调试器与 CI 检查共用
+//
+// 单个插件：
+//   1. .so 必须存在（ErrPluginNotFound）
+//   2. 读取构建工具生成的 <plugin>.so.meta.json（ErrMetaNotFound / ErrInvalidMeta），
+//      在 plugin.Open 之前检查 Go 版本、SDK 版本与目标平台，避免打开不兼容的 .so
+//   3. plugin.Open，查找并调用 NewPlugin（ErrPluginOpenFailed / ErrSymbolNotFound / ErrInvalidPluginType）
+//   4. Info() 未声明的钩子集合与配置 Schema 取自 .meta.json，然后 Validate
+//   5. 指定主程序版本时检查 min_host_version / max_host_version（ErrHostVersionMismatch）
+//
+// 一组插件（Load）：
+//   6. 同名插件只能来自同一个 .so，且实例名不能重复（ErrPluginAlreadyExist）
+//   7. 依赖缺失、版本不满足或成环的插件被拒绝（ErrMissingDependency / ErrDependencyCycle）
+//   8. 按 SortPlugins 计算执行顺序，同一插件的实例相邻；After 成环时只拒绝环中的插件（ErrOrderCycle）
+//
+// 每个被拒绝的插件对应一个 *LoadError，可用 errors.Is 判断原因、errors.As 取得路径。
+//
+// 使用方法：
+//   specs, err := loader.Discover("./data/plugins")
+//   plugins, err := loader.Load(specs, loader.Options{HostVersion: "2.3.0"})
+//   // err 非空时 plugins 仍包含可加载的插件，按 loader.InitOrder(plugins) 初始化
+
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"plugin"
+	"runtime"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// MetaSuffix 构建工具生成的元数据文件后缀，完整文件名为 <plugin>.so.meta.json
+const MetaSuffix = ".meta.json"
+
+// Sidecar 构建工具写入 .meta.json 的元数据
+type Sidecar struct {
+	pluginapi.PluginMeta
+	BuildHost string `json:"build_host"` // 构建主机
+	BuildOS   string `json:"build_os"`   // 构建操作系统
+	BuildArch string `json:"build_arch"` // 构建架构
+}
+
+// Options 加载选项
+type Options struct {
+	HostVersion      string // 主程序版本，用于检查 min_host_version / max_host_version；为空时跳过
+	AllowMissingMeta bool   // 允许没有 .meta.json 的插件（仅用于本地调试，主程序要求该文件）
+}
+
+// Spec 待加载的插件实例
+type Spec struct {
+	Path       string // .so 路径
+	ConfigPath string // 配置文件，为空表示无配置
+	Instance   string // 实例名，为空表示默认实例（见 pluginapi/instance.go）
+}
+
+// Plugin 已加载并通过校验的插件实例（尚未初始化）
+type Plugin struct {
+	Spec
+	Impl    pluginapi.Plugin     // NewPlugin() 返回的插件实现
+	Meta    pluginapi.PluginMeta // Info() 的结果，已合并 .meta.json 中的钩子集合与配置 Schema
+	Sidecar *Sidecar             // .meta.json，AllowMissingMeta 且文件不存在时为 nil
+}
+
+// Name 返回实例的完整名称，默认实例即插件名
+func (p *Plugin) Name() string {
+	return pluginapi.InstanceName(p.Meta.Name, p.Instance)
+}
+
+// LoadError 插件被拒绝的原因
+type LoadError struct {
+	Path string // .so 路径
+	Name string // 实例的完整名称，打开插件之前失败时为空
+	Err  error  // 包装 pluginapi 中的错误
+}
+
+func (e *LoadError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("%s (%s): %v", e.Path, e.Name, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// ReadSidecar 读取 <path>.meta.json
+func ReadSidecar(path string) (*Sidecar, error) {
+	data, err := os.ReadFile(path + MetaSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s%s", pluginapi.ErrMetaNotFound, path, MetaSuffix)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pluginapi.ErrInvalidMeta, err)
+	}
+	var s Sidecar
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%w: %s%s: %v", pluginapi.ErrInvalidMeta, path, MetaSuffix, err)
+	}
+	return &s, nil
+}
+
+// Check 在 plugin.Open 之前检查 .meta.json 记录的构建环境与主程序是否兼容
+func (s *Sidecar) Check() error {
+	if err := pluginapi.CheckGoVersion(s.GoVersion); err != nil {
+		return err
+	}
+	if err := pluginapi.CheckSDKVersion(s.SDKVersion); err != nil {
+		return err
+	}
+	if (s.BuildOS != "" && s.BuildOS != runtime.GOOS) || (s.BuildArch != "" && s.BuildArch != runtime.GOARCH) {
+		return fmt.Errorf("%w: built for %s/%s, host is %s/%s",
+			pluginapi.ErrPlatformMismatch, s.BuildOS, s.BuildArch, runtime.GOOS, runtime.GOARCH)
+	}
+	if len(s.ConfigSchema) > 0 {
+		if err := pluginapi.CheckConfigSchema(s.ConfigSchema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open 按主程序的流程打开单个插件并创建一个实例（步骤 1~5）
+// 每次调用都会调用一次 NewPlugin()；同一个 .so 在进程内只会被 plugin.Open 真正加载一次
+func Open(spec Spec, opts Options) (*Plugin, error) {
+	fail := func(name string, err error) (*Plugin, error) {
+		return nil, &LoadError{Path: spec.Path, Name: name, Err: err}
+	}
+
+	if err := pluginapi.ValidateInstanceName(spec.Instance); err != nil {
+		return fail("", err)
+	}
+	if _, err := os.Stat(spec.Path); errors.Is(err, fs.ErrNotExist) {
+		return fail("", pluginapi.ErrPluginNotFound)
+	} else if err != nil {
+		return fail("", fmt.Errorf("%w: %v", pluginapi.ErrPluginOpenFailed, err))
+	}
+
+	sidecar, err := ReadSidecar(spec.Path)
+	switch {
+	case err == nil:
+		if err := sidecar.Check(); err != nil {
+			return fail("", err)
+		}
+	case errors.Is(err, pluginapi.ErrMetaNotFound) && opts.AllowMissingMeta:
+		sidecar = nil
+	default:
+		return fail("", err)
+	}
+
+	plug, err := newPlugin(spec.Path)
+	if err != nil {
+		return fail("", err)
+	}
+
+	info := plug.Info()
+	if sidecar != nil {
+		if info.Hooks == 0 {
+			info.Hooks = sidecar.Hooks
+		}
+		if len(info.ConfigSchema) == 0 {
+			info.ConfigSchema = sidecar.ConfigSchema
+		}
+	}
+	name := pluginapi.InstanceName(info.Name, spec.Instance)
+	if err := info.Validate(); err != nil {
+		return fail(name, err)
+	}
+	if opts.HostVersion != "" {
+		if err := info.CheckHostVersion(opts.HostVersion); err != nil {
+			return fail(name, err)
+		}
+	}
+	return &Plugin{Spec: spec, Impl: plug, Meta: info, Sidecar: sidecar}, nil
+}
+
+// newPlugin 打开 .so 并调用 NewPlugin，NewPlugin panic 时返回错误
+func newPlugin(path string) (plug pluginapi.Plugin, err error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pluginapi.ErrPluginOpenFailed, err)
+	}
+	sym, err := p.Lookup("NewPlugin")
+	if err != nil {
+		return nil, pluginapi.ErrSymbolNotFound
+	}
+	newFunc, ok := sym.(func() pluginapi.Plugin)
+	if !ok {
+		return nil, pluginapi.ErrInvalidPluginType
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			plug, err = nil, fmt.Errorf("%w: NewPlugin panicked: %v", pluginapi.ErrPluginOpenFailed, v)
+		}
+	}()
+	if plug = newFunc(); plug == nil {
+		return nil, fmt.Errorf("%w: NewPlugin returned nil", pluginapi.ErrInvalidPluginType)
+	}
+	return plug, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Loader Tests
+
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// sidecar 返回与当前主程序兼容的 .meta.json 内容，modify 可修改其中的字段
+func sidecar(t *testing.T, modify func(*Sidecar)) []byte {
+	t.Helper()
+	s := Sidecar{
+		PluginMeta: pluginapi.PluginMeta{Name: "p", Version: "1.0.0", SDKVersion: pluginapi.SDKVersion, GoVersion: runtime.Version()},
+		BuildOS:    runtime.GOOS,
+		BuildArch:  runtime.GOARCH,
+	}
+	if modify != nil {
+		modify(&s)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name     string
+		so       bool   // 是否创建 .so（内容不是有效的插件）
+		meta     []byte // .meta.json 内容，nil 表示不创建
+		instance string
+		opts     Options
+		err      error
+	}{
+		{name: "plugin not found", err: pluginapi.ErrPluginNotFound},
+		{name: "meta not found", so: true, err: pluginapi.ErrMetaNotFound},
+		{name: "meta not required", so: true, opts: Options{AllowMissingMeta: true}, err: pluginapi.ErrPluginOpenFailed},
+		{name: "invalid meta", so: true, meta: []byte("{"), err: pluginapi.ErrInvalidMeta},
+		{name: "platform mismatch", so: true, meta: sidecar(t, func(s *Sidecar) { s.BuildOS = "plan9" }), err: pluginapi.ErrPlatformMismatch},
+		{name: "go version mismatch", so: true, meta: sidecar(t, func(s *Sidecar) { s.GoVersion = "go1.0" }), err: pluginapi.ErrGoVersionMismatch},
+		{name: "sdk version mismatch", so: true, meta: sidecar(t, func(s *Sidecar) { s.SDKVersion = "2.0.0" }), err: pluginapi.ErrSDKVersionMismatch},
+		{name: "missing sdk version", so: true, meta: sidecar(t, func(s *Sidecar) { s.SDKVersion = "" }), err: pluginapi.ErrMissingSDKVersion},
+		{name: "invalid config schema", so: true, meta: sidecar(t, func(s *Sidecar) { s.ConfigSchema = json.RawMessage(`{"oneOf":[]}`) }), err: pluginapi.ErrInvalidConfigSchema},
+		{name: "compatible meta reaches plugin.Open", so: true, meta: sidecar(t, nil), err: pluginapi.ErrPluginOpenFailed},
+		{name: "invalid instance name", so: true, meta: sidecar(t, nil), instance: "bad name", err: pluginapi.ErrInvalidInstanceName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "p.so")
+			if tt.so {
+				if err := os.WriteFile(path, []byte("not a plugin"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.meta != nil {
+				if err := os.WriteFile(path+MetaSuffix, tt.meta, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p, err := Open(Spec{Path: path, Instance: tt.instance}, tt.opts)
+			if p != nil || !errors.Is(err, tt.err) {
+				t.Fatalf("Open = %v, %v; want nil, %v", p, err, tt.err)
+			}
+			var le *LoadError
+			if !errors.As(err, &le) || le.Path != path {
+				t.Fatalf("err %v is not a *LoadError for %s", err, path)
+			}
+		})
+	}
+}
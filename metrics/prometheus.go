@@ -0,0 +1,112 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Prometheus Text Export
+
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType Prometheus 文本格式的 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WritePrometheus 以 Prometheus 文本格式导出所有指标
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	// 复制实例引用，避免导出时持有注册表锁
+	series := make([]map[string]any, len(families))
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+	for i, f := range families {
+		series[i] = make(map[string]any, len(f.series))
+		for k, m := range f.series {
+			series[i][k] = m
+		}
+	}
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for i, f := range families {
+		if len(series[i]) == 0 {
+			continue
+		}
+		bw.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		bw.WriteString("# TYPE " + f.name + " " + string(f.typ) + "\n")
+
+		keys := make([]string, 0, len(series[i]))
+		for k := range series[i] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, labels := range keys {
+			switch m := series[i][labels].(type) {
+			case *counter:
+				writeSample(bw, f.name, labels, m.Value())
+			case *gauge:
+				writeSample(bw, f.name, labels, m.Value())
+			case *histogram:
+				counts, count, sum := m.snapshot()
+				var cumulative uint64
+				for j, upper := range m.buckets {
+					cumulative += counts[j]
+					writeSample(bw, f.name+"_bucket", joinLabels(labels, `le="`+formatFloat(upper)+`"`), float64(cumulative))
+				}
+				writeSample(bw, f.name+"_bucket", joinLabels(labels, `le="+Inf"`), float64(count))
+				writeSample(bw, f.name+"_sum", labels, sum)
+				writeSample(bw, f.name+"_count", labels, float64(count))
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// Handler 返回导出 Prometheus 指标的 HTTP Handler
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WritePrometheus(w)
+	})
+}
+
+func writeSample(w *bufio.Writer, name, labels string, v float64) {
+	w.WriteString(name)
+	if labels != "" {
+		w.WriteString("{" + labels + "}")
+	}
+	w.WriteString(" " + formatFloat(v) + "\n")
+}
+
+func joinLabels(labels, extra string) string {
+	if labels == "" {
+		return extra
+	}
+	return labels + "," + extra
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string       { return helpEscaper.Replace(s) }
+func escapeLabelValue(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,265 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Metrics Registry
+//
+// pluginapi.Metrics 的参考实现，供主程序与本地调试器使用
+//
+// 使用方法：
+//   reg := metrics.NewRegistry()
+//   m := reg.ForPlugin("my_plugin")             // 传给插件的 pluginapi.Metrics
+//   m := reg.ForInstance("webhook", "billing")  // 命名实例，附带 plugin_instance 标签
+//   h := reg.ForHost("webhook", "billing")     // 主程序为插件导出的指标（如健康状态），可使用保留名称
+//   reg.WritePrometheus(os.Stdout)              // 导出 Prometheus 文本格式
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+type metricType string
+
+const (
+	typeCounter   metricType = "counter"
+	typeGauge     metricType = "gauge"
+	typeHistogram metricType = "histogram"
+)
+
+// family 同名指标族
+// 不同插件可以注册同名指标（以 plugin 标签区分），导出时使用第一个注册者的帮助信息；
+// 帮助信息与分桶只在同一插件内要求一致，类型是整个指标族的属性，必须一致
+type family struct {
+	name   string
+	help   string
+	typ    metricType
+	owners map[string]owner // 插件名 -> 该插件注册时的帮助信息与分桶
+	series map[string]any   // 标签串 -> *counter/*gauge/*histogram
+}
+
+// owner 单个插件对指标族的声明
+type owner struct {
+	help    string
+	buckets []float64
+}
+
+// Registry 指标注册表，所有插件共享
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// ForPlugin 返回带 plugin 标签的 pluginapi.Metrics
+func (r *Registry) ForPlugin(name string) pluginapi.Metrics {
+	return r.ForInstance(name, "")
+}
+
+// ForInstance 返回带 plugin 与 plugin_instance 标签的 pluginapi.Metrics，instance 为空时同 ForPlugin
+func (r *Registry) ForInstance(plugin, instance string) pluginapi.Metrics {
+	return &pluginMetrics{reg: r, plugin: plugin, instance: instance}
+}
+
+// ForHost 返回主程序为插件导出指标使用的视图，与 ForInstance 的区别是允许 pluginapi.IsReservedMetric 中的名称
+// 不能传给插件
+func (r *Registry) ForHost(plugin, instance string) pluginapi.Metrics {
+	return &pluginMetrics{reg: r, plugin: plugin, instance: instance, host: true}
+}
+
+// getOrCreate 获取或创建指标实例
+func (r *Registry) getOrCreate(plugin, name, help string, typ metricType, buckets []float64, labels pluginapi.Labels, create func() any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{name: name, help: help, typ: typ, owners: make(map[string]owner), series: make(map[string]any)}
+		r.families[name] = f
+	} else if f.typ != typ {
+		return nil, fmt.Errorf("%w: %s already registered as %s", pluginapi.ErrInvalidMetric, name, f.typ)
+	}
+	if o, ok := f.owners[plugin]; !ok {
+		f.owners[plugin] = owner{help: help, buckets: buckets}
+	} else if o.help != help {
+		return nil, fmt.Errorf("%w: %s registered with different help", pluginapi.ErrInvalidMetric, name)
+	} else if typ == typeHistogram && !equalBuckets(o.buckets, buckets) {
+		return nil, fmt.Errorf("%w: %s registered with different buckets", pluginapi.ErrInvalidMetric, name)
+	}
+
+	key := labelString(labels)
+	m, ok := f.series[key]
+	if !ok {
+		m = create()
+		f.series[key] = m
+	}
+	return m, nil
+}
+
+// pluginMetrics 单个插件的指标视图
+type pluginMetrics struct {
+	reg      *Registry
+	plugin   string
+	instance string
+	host     bool // 主程序视图，允许保留名称
+}
+
+func (p *pluginMetrics) prepare(name string, labels pluginapi.Labels) (string, pluginapi.Labels, error) {
+	if !p.host || !pluginapi.IsReservedMetric(name) {
+		if err := pluginapi.ValidateMetric(name, labels); err != nil {
+			return "", nil, err
+		}
+	}
+	all := make(pluginapi.Labels, len(labels)+2)
+	for k, v := range labels {
+		all[k] = v
+	}
+	all[pluginapi.MetricLabelPlugin] = p.plugin
+	if p.instance != "" {
+		all[pluginapi.MetricLabelInstance] = p.instance
+	}
+	return pluginapi.MetricPrefix + name, all, nil
+}
+
+func (p *pluginMetrics) Counter(name, help string, labels pluginapi.Labels) (pluginapi.Counter, error) {
+	full, all, err := p.prepare(name, labels)
+	if err != nil {
+		return nil, err
+	}
+	m, err := p.reg.getOrCreate(p.plugin, full, help, typeCounter, nil, all, func() any { return &counter{} })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*counter), nil
+}
+
+func (p *pluginMetrics) Gauge(name, help string, labels pluginapi.Labels) (pluginapi.Gauge, error) {
+	full, all, err := p.prepare(name, labels)
+	if err != nil {
+		return nil, err
+	}
+	m, err := p.reg.getOrCreate(p.plugin, full, help, typeGauge, nil, all, func() any { return &gauge{} })
+	if err != nil {
+		return nil, err
+	}
+	return m.(*gauge), nil
+}
+
+func (p *pluginMetrics) Histogram(name, help string, buckets []float64, labels pluginapi.Labels) (pluginapi.Histogram, error) {
+	full, all, err := p.prepare(name, labels)
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		buckets = pluginapi.DefaultBuckets
+	}
+	if err := pluginapi.ValidateBuckets(buckets); err != nil {
+		return nil, err
+	}
+	buckets = append([]float64(nil), buckets...)
+	m, err := p.reg.getOrCreate(p.plugin, full, help, typeHistogram, buckets, all, func() any {
+		return &histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.(*histogram), nil
+}
+
+// atomicFloat 原子 float64
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := f.bits.Load()
+		v := math.Float64frombits(old) + delta
+		if f.bits.CompareAndSwap(old, math.Float64bits(v)) {
+			return
+		}
+	}
+}
+
+func (f *atomicFloat) load() float64   { return math.Float64frombits(f.bits.Load()) }
+func (f *atomicFloat) store(v float64) { f.bits.Store(math.Float64bits(v)) }
+
+type counter struct{ v atomicFloat }
+
+func (c *counter) Inc() { c.v.add(1) }
+func (c *counter) Add(delta float64) {
+	if delta > 0 {
+		c.v.add(delta)
+	}
+}
+func (c *counter) Value() float64 { return c.v.load() }
+
+type gauge struct{ v atomicFloat }
+
+func (g *gauge) Set(v float64)     { g.v.store(v) }
+func (g *gauge) Inc()              { g.v.add(1) }
+func (g *gauge) Dec()              { g.v.add(-1) }
+func (g *gauge) Add(delta float64) { g.v.add(delta) }
+func (g *gauge) Value() float64    { return g.v.load() }
+
+type histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64 // 非累计计数，导出时累加
+	count   uint64
+	sum     float64
+}
+
+func (h *histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.mu.Lock()
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += v
+	h.mu.Unlock()
+}
+
+func (h *histogram) snapshot() (counts []uint64, count uint64, sum float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]uint64(nil), h.counts...), h.count, h.sum
+}
+
+// labelString 生成排序后的标签串，如 `a="1",plugin="x"`
+func labelString(labels pluginapi.Labels) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, k, escapeLabelValue(labels[k]))
+	}
+	return b.String()
+}
+
+func equalBuckets(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
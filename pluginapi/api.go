@@ -12,6 +12,7 @@ type Plugin interface {
 
 	// Init 插件初始化
 	// 加载成功后调用一次，config 为插件配置内容（可为空）
+	// 配置总是 JSON：YAML 配置文件由主程序转换为 JSON 后传入，插件只需 json.Unmarshal
 	// 返回 error 将导致插件加载失败
 	Init(config []byte) error
 
@@ -58,3 +59,17 @@ func (BasePlugin) OnSubscribe(ctx *SubscribeContext) (bool, error) { return true
 func (BasePlugin) OnPublish(ctx *PublishContext)                   {}
 func (BasePlugin) OnDisconnect(ctx *DisconnectContext)             {}
 func (BasePlugin) Close() error                                    { return nil }
+
+// Reconfigurable 可选接口：支持在不重新加载 .so 的情况下更新配置
+// 插件配置文件变化时，主程序调用 Reconfigure 代替热加载整个插件
+//
+// 调用约定：
+//   - Reconfigure 不会与自身并发调用，但可能与钩子并发执行，插件应整体替换配置（如 atomic.Pointer）
+//   - 返回 error 表示拒绝新配置，插件必须保持旧配置继续生效；主程序记录错误并保留旧配置
+//   - 接受新配置后，主程序同时应用其中的 log_level
+//   - 与 Init 相同，config 总是 JSON
+//
+// 未实现此接口的插件在配置变化时需要重新加载 .so 才能生效。
+type Reconfigurable interface {
+	Reconfigure(config []byte) error
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Batched Publish Delivery
+
+package pluginapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// 批量投递参数
+const (
+	DefaultBatchSize   = 100                   // 默认每批最多消息数
+	MaxBatchSize       = 10000                 // 最大允许每批消息数
+	DefaultBatchLinger = 10 * time.Millisecond // 默认攒批等待时间
+	MaxBatchLinger     = 5 * time.Second       // 最大允许攒批等待时间
+)
+
+// BatchPublisher 可选接口：批量接收 OnPublish 事件，适合写入文件、Kafka、数据库的 sink
+//
+// 实现此接口（且声明了 HookPublish）的插件不再收到 OnPublish，主程序为其缓冲消息，
+// 满足以下任一条件时调用一次 OnPublishBatch：
+//   - 缓冲达到 PluginMeta.BatchSize 条
+//   - 第一条消息进入缓冲后经过 PluginMeta.BatchLinger
+//   - 关闭或热升级插件（见下文）
+//
+// 调用约定：
+//   - 批内消息按到达顺序排列；同一插件的 OnPublishBatch 不会并发调用，
+//     超时的调用返回之前到达的批次整批跳过
+//   - PublishFilter 在进入缓冲之前应用，健康检查为不可用时消息不进入缓冲
+//   - batch 切片及其中的 Payload 在调用返回后由主程序复用，需要保留时使用 ClonePublishBatch
+//   - 超时按 OnPublish 的策略处理（见 HookPolicy），整批跳过；耗时较长的 sink 应放宽 HookPublish 的超时
+//
+// 关闭与热升级：主程序在第一阶段停止接收后，先将缓冲中不足一批的消息投递给 OnPublishBatch，
+// 再调用 Drainer.Drain（热升级时在 Snapshot 之前投递），因此 Drain 与 Snapshot 能看到全部已接收的消息。
+type BatchPublisher interface {
+	OnPublishBatch(batch []PublishContext)
+}
+
+// validateBatch 校验批量投递参数，超出范围时返回 ErrInvalidBatch（0 表示默认值）
+func (m *PluginMeta) validateBatch() error {
+	if m.BatchSize < 0 || m.BatchSize > MaxBatchSize {
+		return fmt.Errorf("%w: batch_size %d must be between 0 and %d", ErrInvalidBatch, m.BatchSize, MaxBatchSize)
+	}
+	if m.BatchLinger < 0 || m.BatchLinger > MaxBatchLinger {
+		return fmt.Errorf("%w: batch_linger %v must be between 0 and %v", ErrInvalidBatch, m.BatchLinger, MaxBatchLinger)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Broker State
+
+package pluginapi
+
+import "time"
+
+// BrokerState Broker 运行状态的只读查询
+// 查询结果是调用时刻的快照，插件不应修改返回的数据
+type BrokerState interface {
+	// Retained 返回主题上的保留消息，topic 不能包含通配符
+	Retained(topic string) (msg *Message, ok bool, err error)
+
+	// Topics 返回主题树中匹配 filter 的主题（有保留消息或近期有消息发布），按字典序
+	// limit <= 0 表示不限；主题很多时请指定 limit
+	Topics(filter string, limit int) ([]string, error)
+
+	// SubscriberCount 返回会收到该主题消息的订阅数（含离线的持久会话）
+	SubscriberCount(topic string) (int, error)
+
+	// Stats 返回 Broker 统计，与 $SYS 主题的内容一致
+	Stats() BrokerStats
+}
+
+// BrokerStats Broker 统计
+// 速率为最近 1 分钟的平均值（条/秒），对应 $SYS/broker/load/.../1min
+type BrokerStats struct {
+	Uptime           time.Duration // $SYS/broker/uptime
+	ClientsConnected int           // $SYS/broker/clients/connected
+	ClientsTotal     int           // $SYS/broker/clients/total（含离线的持久会话）
+	Subscriptions    int           // $SYS/broker/subscriptions/count
+	RetainedMessages int           // $SYS/broker/retained messages/count
+	MessagesReceived uint64        // $SYS/broker/messages/received
+	MessagesSent     uint64        // $SYS/broker/messages/sent
+	BytesReceived    uint64        // $SYS/broker/bytes/received
+	BytesSent        uint64        // $SYS/broker/bytes/sent
+	ReceivedRate     float64       // $SYS/broker/load/messages/received/1min
+	SentRate         float64       // $SYS/broker/load/messages/sent/1min
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Cluster Topology
+
+package pluginapi
+
+// Cluster 集群拓扑信息
+// 单节点部署时 Members 只包含本节点，IsLeader 恒为 true
+//
+// 只需在集群中执行一次的工作（如周期报表）应在每次执行时检查 IsLeader，
+// 不要在 Init 中判断一次后缓存结果：
+//
+//	host.Scheduler().Every("report", time.Minute, func(ctx context.Context) {
+//	    if !host.Cluster().IsLeader() {
+//	        return
+//	    }
+//	    // ...
+//	})
+//
+// 网络分区或选举期间可能短暂出现两个节点同时认为自己是 leader，或没有 leader，
+// 单例工作应当幂等。
+type Cluster interface {
+	// NodeID 本节点 ID
+	NodeID() string
+
+	// Members 当前集群成员（含本节点，按 ID 排序）
+	Members() []Member
+
+	// Leader 当前 leader 的节点 ID，选举期间为空
+	Leader() string
+
+	// IsLeader 本节点是否为 leader
+	IsLeader() bool
+}
+
+// Member 集群成员
+type Member struct {
+	ID      string // 节点 ID
+	Address string // 集群通信地址
+	Leader  bool   // 是否为 leader
+	Self    bool   // 是否为本节点
+}
+
+// TopologyEventType 拓扑变化类型
+type TopologyEventType int
+
+const (
+	MemberJoined  TopologyEventType = iota + 1 // 节点加入
+	MemberLeft                                 // 节点离开（含失联）
+	LeaderChanged                              // leader 变更
+)
+
+// String 返回事件类型名称
+func (t TopologyEventType) String() string {
+	switch t {
+	case MemberJoined:
+		return "member_joined"
+	case MemberLeft:
+		return "member_left"
+	case LeaderChanged:
+		return "leader_changed"
+	default:
+		return "unknown"
+	}
+}
+
+// TopologyEvent 拓扑变化事件
+type TopologyEvent struct {
+	Type     TopologyEventType
+	Node     string // 加入/离开的节点，或新的 leader（选举期间为空）
+	Leader   string // 事件发生后的 leader
+	IsLeader bool   // 事件发生后本节点是否为 leader
+}
+
+// TopologyWatcher 可选接口：需要感知集群拓扑或 leader 变化的插件实现此接口
+// 回调在主程序的集群协程中同步执行，不应长时间阻塞；耗时工作请交给 Scheduler
+type TopologyWatcher interface {
+	OnTopologyChange(event TopologyEvent)
+}
@@ -0,0 +1,447 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Config Schema
+
+package pluginapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConfigSchemaFile 插件源码目录中的配置 Schema 文件名
+// 构建工具读取该文件写入 .meta.json 的 config_schema，插件可通过 //go:embed 在 Info() 中返回同一份内容
+const ConfigSchemaFile = "config.schema.json"
+
+// 配置校验
+//
+// PluginMeta.ConfigSchema 为 JSON Schema（draft-07 的常用子集），主程序在调用 Init / Reconfigure
+// 之前校验配置，不通过时不调用插件并报告每一处错误的位置（如 "/users/admin: expected string, got number"）。
+//
+// 支持的关键字：type、properties、required、additionalProperties、items、enum、const、
+// minimum、maximum、exclusiveMinimum、exclusiveMaximum、minLength、maxLength、pattern、
+// minItems、maxItems、minProperties、maxProperties。
+// 说明性关键字（title、description、default、examples 等）被忽略；
+// 其他校验关键字（oneOf、anyOf、allOf、$ref、patternProperties、format 等）未实现，
+// CheckConfigSchema 拒绝包含它们的 Schema，避免插件作者误以为配置已按其校验。
+//
+// 空配置按 {} 校验，因此带默认值的字段不应列入 required。
+// YAML 配置由主程序与本地调试器转换为 JSON 后校验并传给插件（见 loader.ConfigJSON），插件总是收到 JSON。
+
+// configSchema 解析后的 Schema
+type configSchema struct {
+	Type                 schemaTypes              `json:"type"`
+	Properties           map[string]*configSchema `json:"properties"`
+	Required             []string                 `json:"required"`
+	AdditionalProperties *additionalSchema        `json:"additionalProperties"`
+	Items                *configSchema            `json:"items"`
+	Enum                 []json.RawMessage        `json:"enum"`
+	Const                json.RawMessage          `json:"const"`
+	Minimum              *float64                 `json:"minimum"`
+	Maximum              *float64                 `json:"maximum"`
+	ExclusiveMinimum     *float64                 `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                 `json:"exclusiveMaximum"`
+	MinLength            *int                     `json:"minLength"`
+	MaxLength            *int                     `json:"maxLength"`
+	Pattern              string                   `json:"pattern"`
+	MinItems             *int                     `json:"minItems"`
+	MaxItems             *int                     `json:"maxItems"`
+	MinProperties        *int                     `json:"minProperties"`
+	MaxProperties        *int                     `json:"maxProperties"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaKeywords 支持的关键字：true 为校验关键字，false 为被忽略的说明性关键字
+var schemaKeywords = map[string]bool{
+	"type": true, "properties": true, "required": true, "additionalProperties": true, "items": true,
+	"enum": true, "const": true, "minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+	"minLength": true, "maxLength": true, "pattern": true, "minItems": true, "maxItems": true,
+	"minProperties": true, "maxProperties": true,
+
+	"$schema": false, "$id": false, "$comment": false, "title": false, "description": false,
+	"default": false, "examples": false, "readOnly": false, "writeOnly": false, "deprecated": false,
+}
+
+// checkKeywords 拒绝未实现的关键字，递归检查 properties、additionalProperties 与 items 中的子 Schema
+func checkKeywords(path string, data json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil // 非对象（如 additionalProperties: false）由解析阶段处理
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := schemaKeywords[name]; !ok {
+			return fmt.Errorf("%w: %s: unsupported keyword %q", ErrInvalidConfigSchema, path, name)
+		}
+	}
+
+	if raw, ok := fields["properties"]; ok {
+		var props map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &props); err == nil {
+			names = names[:0]
+			for name := range props {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if err := checkKeywords(path+"/properties/"+name, props[name]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, key := range []string{"additionalProperties", "items"} {
+		if raw, ok := fields[key]; ok {
+			if err := checkKeywords(path+"/"+key, raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes type 关键字，可以是字符串或字符串数组
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = many
+	return nil
+}
+
+// additionalSchema additionalProperties 关键字，可以是布尔值或 Schema
+type additionalSchema struct {
+	allowed bool
+	schema  *configSchema
+}
+
+func (a *additionalSchema) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// CheckConfigSchema 校验 Schema 本身是否有效
+func CheckConfigSchema(schema []byte) error {
+	_, err := parseConfigSchema(schema)
+	return err
+}
+
+// ValidateConfig 按 Schema 校验配置，schema 为空时不校验
+// 配置不是合法 JSON 或不符合 Schema 时返回 ErrInvalidConfig，列出所有错误
+func ValidateConfig(schema, config []byte) error {
+	if len(bytes.TrimSpace(schema)) == 0 {
+		return nil
+	}
+	s, err := parseConfigSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	config = bytes.TrimSpace(config)
+	if len(config) == 0 {
+		config = []byte("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(config))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if dec.More() {
+		return fmt.Errorf("%w: unexpected data after top-level value", ErrInvalidConfig)
+	}
+
+	var problems []string
+	s.validate("", doc, &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parseConfigSchema 解析并预编译 Schema
+func parseConfigSchema(schema []byte) (*configSchema, error) {
+	var s configSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfigSchema, err)
+	}
+	if err := checkKeywords("#", schema); err != nil {
+		return nil, err
+	}
+	if err := s.compile("#"); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile 检查类型名、编译正则
+func (s *configSchema) compile(path string) error {
+	for _, t := range s.Type {
+		switch t {
+		case "object", "array", "string", "number", "integer", "boolean", "null":
+		default:
+			return fmt.Errorf("%w: %s: unknown type %q", ErrInvalidConfigSchema, path, t)
+		}
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %s: pattern: %v", ErrInvalidConfigSchema, path, err)
+		}
+		s.pattern = re
+	}
+	for name, prop := range s.Properties {
+		if prop == nil {
+			return fmt.Errorf("%w: %s/properties/%s: schema is null", ErrInvalidConfigSchema, path, name)
+		}
+		if err := prop.compile(path + "/properties/" + name); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.schema != nil {
+		if err := s.AdditionalProperties.schema.compile(path + "/additionalProperties"); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(path + "/items"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate 校验一个值，错误追加到 problems
+func (s *configSchema) validate(path string, v any, problems *[]string) {
+	at := path
+	if at == "" {
+		at = "/"
+	}
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(v) {
+		fail("expected %s, got %s", strings.Join(s.Type, " or "), jsonType(v))
+		return
+	}
+	if s.Const != nil && !jsonEqual(v, s.Const) {
+		fail("must be %s", s.Const)
+	}
+	if len(s.Enum) > 0 {
+		ok := false
+		for _, e := range s.Enum {
+			if jsonEqual(v, e) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			allowed := make([]string, len(s.Enum))
+			for i, e := range s.Enum {
+				allowed[i] = string(e)
+			}
+			fail("must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required field %q", name)
+			}
+		}
+		if s.MinProperties != nil && len(v) < *s.MinProperties {
+			fail("must have at least %d field(s)", *s.MinProperties)
+		}
+		if s.MaxProperties != nil && len(v) > *s.MaxProperties {
+			fail("must have at most %d field(s)", *s.MaxProperties)
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child := path + "/" + name
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(child, v[name], problems)
+				continue
+			}
+			if name == ConfigKeyLogLevel && path == "" {
+				continue // 主程序处理的保留字段，只在顶层
+			}
+			switch ap := s.AdditionalProperties; {
+			case ap == nil:
+			case !ap.allowed:
+				*problems = append(*problems, child+": unknown field"+suggestField(name, s.Properties))
+			case ap.schema != nil:
+				ap.schema.validate(child, v[name], problems)
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d item(s)", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d item(s)", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(path+"/"+strconv.Itoa(i), item, problems)
+			}
+		}
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("must be at least %d character(s)", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("must be at most %d character(s)", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %q", s.Pattern)
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("must be <= %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && f <= *s.ExclusiveMinimum {
+			fail("must be > %v", *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && f >= *s.ExclusiveMaximum {
+			fail("must be < %v", *s.ExclusiveMaximum)
+		}
+	}
+}
+
+// matchesType 判断值是否属于 type 中的任一类型
+func (s *configSchema) matchesType(v any) bool {
+	actual := jsonType(v)
+	for _, t := range s.Type {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType 返回值的 JSON Schema 类型名
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) && !strings.ContainsAny(v.String(), ".eE") {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// jsonEqual 比较值与 Schema 中的字面量
+func jsonEqual(v any, literal json.RawMessage) bool {
+	dec := json.NewDecoder(bytes.NewReader(literal))
+	dec.UseNumber()
+	var want any
+	if err := dec.Decode(&want); err != nil {
+		return false
+	}
+	a, errA := json.Marshal(normalizeNumbers(v))
+	b, errB := json.Marshal(normalizeNumbers(want))
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// normalizeNumbers 将数字统一为 float64，使 1 与 1.0 相等
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = normalizeNumbers(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = normalizeNumbers(e)
+		}
+		return out
+	}
+	return v
+}
+
+// suggestField 为拼写错误的字段给出最接近的已知字段
+func suggestField(name string, known map[string]*configSchema) string {
+	best, bestDist := "", 3
+	for k := range known {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(k)); d < bestDist || (d == bestDist && best != "" && k < best) {
+			best, bestDist = k, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance 计算两个字符串的编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Config Schema Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckConfigSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		err    string // 为空表示有效
+	}{
+		{"supported", `{"type":"object","properties":{"a":{"type":"string","pattern":"^x"}},"additionalProperties":false}`, ""},
+		{"annotations ignored", `{"$schema":"http://json-schema.org/draft-07/schema#","title":"t","description":"d","properties":{"a":{"default":1,"examples":[1]}}}`, ""},
+		{"top-level oneOf", `{"oneOf":[{"type":"string"}]}`, `#: unsupported keyword "oneOf"`},
+		{"nested format", `{"properties":{"email":{"type":"string","format":"email"}}}`, `#/properties/email: unsupported keyword "format"`},
+		{"ref in items", `{"type":"array","items":{"$ref":"#/definitions/x"}}`, `#/items: unsupported keyword "$ref"`},
+		{"patternProperties in additionalProperties", `{"additionalProperties":{"patternProperties":{"^x":{}}}}`, `#/additionalProperties: unsupported keyword "patternProperties"`},
+		{"allOf", `{"properties":{"a":{"allOf":[]}}}`, `unsupported keyword "allOf"`},
+		{"unknown type", `{"type":"float"}`, `unknown type "float"`},
+		{"bad pattern", `{"pattern":"("}`, `#: pattern`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckConfigSchema([]byte(tt.schema))
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfigSchema) || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("err = %v, want ErrInvalidConfigSchema containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"db": {"type": "object", "properties": {"host": {"type": "string"}}, "additionalProperties": false},
+			"timeout_ms": {"type": "integer", "minimum": 1}
+		},
+		"additionalProperties": false
+	}`)
+	tests := []struct {
+		name   string
+		config string
+		err    string // 为空表示通过
+	}{
+		{"empty", ``, ""},
+		{"valid", `{"db":{"host":"x"},"timeout_ms":5}`, ""},
+		{"top-level log_level reserved", `{"log_level":"debug"}`, ""},
+		{"nested log_level validated", `{"db":{"log_level":"debug"}}`, "/db/log_level: unknown field"},
+		{"unknown field", `{"timeout":5}`, "/timeout: unknown field"},
+		{"wrong type", `{"timeout_ms":"5"}`, "/timeout_ms: expected integer, got string"},
+		{"below minimum", `{"timeout_ms":0}`, "/timeout_ms: must be >= 1"},
+		{"not json", `timeout_ms: 5`, "invalid character"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(schema, []byte(tt.config))
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("err = %v, want ErrInvalidConfig containing %q", err, tt.err)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 // Copyright 2025 AXMQ Authors
 // AXMQ Plugin SDK - Hook Contexts
+//
+// 生命周期约定：
+//   - 默认情况下（与 1.0 一致）每个插件收到独立的 PublishContext，Payload 是可以在返回后继续引用的副本
+//   - 插件声明 PluginMeta.SharedPublish 后，主程序从对象池取出 PublishContext，由所有声明了的插件共享，
+//     避免逐插件复制：此时上下文与 Payload 只读，且只在 OnPublish 调用期间有效，返回后被复用
+//   - 共享的上下文需要在返回后继续使用（如交给 goroutine、放入缓冲）时，先调用 Clone 取得独立副本
+//   - 不要修改上下文的输入字段，输出字段（ThreatScore）是唯一允许插件写入的字段
 
 package pluginapi
 
@@ -9,7 +16,7 @@ type AuthContext struct {
 	// 输入字段（主程序填充）
 	ClientID string // 客户端 ID
 	Username string // 用户名
-	Password []byte // 密码
+	Password []byte // 密码（调用返回后失效）
 	IP       string // 客户端 IP 地址
 
 	// 输出字段（插件可设置）
@@ -37,7 +44,7 @@ type PublishContext struct {
 	ClientID string // 发布者客户端 ID
 	Username string // 发布者用户名
 	Topic    string // 发布主题
-	Payload  []byte // 消息内容（只读副本）
+	Payload  []byte // 消息内容（只读；默认为副本，声明 SharedPublish 时为共享缓冲区，调用返回后失效）
 	QoS      uint8  // QoS 等级
 	Retain   bool   // 是否为保留消息
 }
@@ -47,5 +54,49 @@ type PublishContext struct {
 type DisconnectContext struct {
 	ClientID string // 客户端 ID
 	Username string // 用户名
-	Reason   string // 断开原因（graceful/timeout/error）
+	Reason   string // 断开原因（graceful/timeout/error/kicked）
+}
+
+// Clone 返回独立副本，可在钩子返回后继续使用
+func (c *AuthContext) Clone() *AuthContext {
+	clone := *c
+	clone.Password = cloneBytes(c.Password)
+	return &clone
+}
+
+// Clone 返回独立副本，可在钩子返回后继续使用
+func (c *SubscribeContext) Clone() *SubscribeContext {
+	clone := *c
+	return &clone
+}
+
+// Clone 返回独立副本（复制 Payload），可在钩子返回后继续使用或修改
+func (c *PublishContext) Clone() *PublishContext {
+	clone := *c
+	clone.Payload = cloneBytes(c.Payload)
+	return &clone
+}
+
+// Clone 返回独立副本，可在钩子返回后继续使用
+func (c *DisconnectContext) Clone() *DisconnectContext {
+	clone := *c
+	return &clone
+}
+
+// ClonePublishBatch 复制 OnPublishBatch 收到的批次（含每条消息的 Payload），可在调用返回后继续使用
+func ClonePublishBatch(batch []PublishContext) []PublishContext {
+	clones := make([]PublishContext, len(batch))
+	for i := range batch {
+		clones[i] = batch[i]
+		clones[i].Payload = cloneBytes(batch[i].Payload)
+	}
+	return clones
+}
+
+// cloneBytes 复制字节切片，nil 保持为 nil
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
 }
@@ -0,0 +1,223 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Host Constraints and Plugin Dependencies
+
+package pluginapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Dependency 插件依赖的其他插件
+// 主程序先初始化依赖，再初始化依赖它的插件，关闭时顺序相反；依赖缺失或版本不满足时拒绝加载
+type Dependency struct {
+	Name    string `json:"name"`              // 依赖的插件名称
+	Version string `json:"version,omitempty"` // 版本范围，如 "^1.2"、">=1.0.0 <3"（空表示任意版本，见 VersionRange）
+}
+
+// validateDescriptive 校验描述信息
+func (m *PluginMeta) validateDescriptive() error {
+	if m.Homepage == "" {
+		return nil
+	}
+	u, err := url.Parse(m.Homepage)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q (must be an http or https URL)", ErrInvalidHomepage, m.Homepage)
+	}
+	return nil
+}
+
+// validateDependencies 校验主程序版本约束与依赖声明
+func (m *PluginMeta) validateDependencies() error {
+	var minV, maxV Version
+	var err error
+	if m.MinHostVersion != "" {
+		if minV, err = ParseVersion(m.MinHostVersion); err != nil {
+			return fmt.Errorf("%w: min_host_version: %v", ErrInvalidHostVersion, err)
+		}
+	}
+	if m.MaxHostVersion != "" {
+		if maxV, err = ParseVersion(m.MaxHostVersion); err != nil {
+			return fmt.Errorf("%w: max_host_version: %v", ErrInvalidHostVersion, err)
+		}
+	}
+	if m.MinHostVersion != "" && m.MaxHostVersion != "" && minV.Compare(maxV) > 0 {
+		return fmt.Errorf("%w: min_host_version %s is newer than max_host_version %s", ErrInvalidHostVersion, m.MinHostVersion, m.MaxHostVersion)
+	}
+
+	seen := make(map[string]bool, len(m.Dependencies))
+	for _, dep := range m.Dependencies {
+		switch {
+		case dep.Name == "":
+			return fmt.Errorf("%w: empty plugin name", ErrInvalidDependency)
+		case dep.Name == m.Name:
+			return fmt.Errorf("%w: plugin %q cannot depend on itself", ErrInvalidDependency, m.Name)
+		case seen[dep.Name]:
+			return fmt.Errorf("%w: duplicate dependency %q", ErrInvalidDependency, dep.Name)
+		}
+		seen[dep.Name] = true
+		if _, err := ParseVersionRange(dep.Version); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidDependency, dep.Name, err)
+		}
+	}
+	return nil
+}
+
+// CheckHostVersion 检查主程序版本是否在 MinHostVersion ~ MaxHostVersion 之间（均包含边界，未设置的一侧不限制）
+func (m *PluginMeta) CheckHostVersion(hostVersion string) error {
+	if m.MinHostVersion == "" && m.MaxHostVersion == "" {
+		return nil
+	}
+	host, err := ParseVersion(hostVersion)
+	if err != nil {
+		return err
+	}
+	if m.MinHostVersion != "" {
+		if minV, err := ParseVersion(m.MinHostVersion); err == nil && host.Compare(minV) < 0 {
+			return fmt.Errorf("%w: %s requires AXMQ >= %s, host is %s", ErrHostVersionMismatch, m.Name, m.MinHostVersion, hostVersion)
+		}
+	}
+	if m.MaxHostVersion != "" {
+		if maxV, err := ParseVersion(m.MaxHostVersion); err == nil && host.Compare(maxV) > 0 {
+			return fmt.Errorf("%w: %s supports AXMQ <= %s, host is %s", ErrHostVersionMismatch, m.Name, m.MaxHostVersion, hostVersion)
+		}
+	}
+	return nil
+}
+
+// DependencyError 因依赖被拒绝的插件及原因，可用 errors.As 取得插件名
+type DependencyError struct {
+	Plugin string // 被拒绝的插件名称
+	Err    error  // ErrMissingDependency / ErrInvalidDependency / ErrDependencyCycle
+}
+
+func (e *DependencyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DependencyError) Unwrap() error {
+	return e.Err
+}
+
+// ResolveDependencies 计算加载顺序：依赖先于依赖它的插件，其余按名称排序保证结果稳定
+//
+// 依赖缺失、版本不满足或处于依赖环中的插件被拒绝，依赖被拒绝插件的插件也被拒绝；
+// ordered 只包含可以加载的插件，err 汇总每个被拒绝的插件（errors.Join 的 *DependencyError）。
+// 名称重复时返回 ErrPluginAlreadyExist 且 ordered 为 nil。
+func ResolveDependencies(metas []PluginMeta) (ordered []PluginMeta, err error) {
+	index := make(map[string]int, len(metas))
+	for i := range metas {
+		if _, ok := index[metas[i].Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrPluginAlreadyExist, metas[i].Name)
+		}
+		index[metas[i].Name] = i
+	}
+
+	// 1. 直接依赖缺失或版本不满足
+	refused := make(map[int]error)
+	for i := range metas {
+		if e := checkDependencies(&metas[i], metas, index); e != nil {
+			refused[i] = e
+		}
+	}
+
+	// 2. 传递拒绝：依赖了被拒绝的插件
+	for changed := true; changed; {
+		changed = false
+		for i := range metas {
+			if refused[i] != nil {
+				continue
+			}
+			for _, dep := range metas[i].Dependencies {
+				if j := index[dep.Name]; refused[j] != nil {
+					refused[i] = fmt.Errorf("%w: %s requires %s, which was refused", ErrMissingDependency, metas[i].Name, dep.Name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	// 3. 拓扑排序，剩余的即处于依赖环中
+	indegree := make([]int, len(metas))
+	next := make([][]int, len(metas))
+	for i := range metas {
+		if refused[i] != nil {
+			continue
+		}
+		for _, dep := range metas[i].Dependencies {
+			j := index[dep.Name]
+			indegree[i]++
+			next[j] = append(next[j], i)
+		}
+	}
+	var ready []int
+	for i := range metas {
+		if refused[i] == nil && indegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool { return metas[ready[a]].Name < metas[ready[b]].Name })
+		cur := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, metas[cur])
+		for _, n := range next[cur] {
+			indegree[n]--
+			if indegree[n] == 0 {
+				ready = append(ready, n)
+			}
+		}
+	}
+	var cycle []string
+	for i := range metas {
+		if refused[i] == nil && indegree[i] > 0 {
+			cycle = append(cycle, metas[i].Name)
+		}
+	}
+	sort.Strings(cycle)
+	for _, name := range cycle {
+		refused[index[name]] = fmt.Errorf("%w: %s (cycle: %s)", ErrDependencyCycle, name, strings.Join(cycle, ", "))
+	}
+
+	errs := make([]error, 0, len(refused))
+	for i := range metas {
+		if refused[i] != nil {
+			errs = append(errs, &DependencyError{Plugin: metas[i].Name, Err: refused[i]})
+		}
+	}
+	return ordered, errors.Join(errs...)
+}
+
+// checkDependencies 检查插件的直接依赖是否都已提供且版本满足
+func checkDependencies(m *PluginMeta, metas []PluginMeta, index map[string]int) error {
+	for _, dep := range m.Dependencies {
+		j, ok := index[dep.Name]
+		if !ok {
+			return fmt.Errorf("%w: %s requires %s %s, which is not loaded", ErrMissingDependency, m.Name, dep.Name, dep.rangeString())
+		}
+		if dep.Version == "" {
+			continue
+		}
+		r, err := ParseVersionRange(dep.Version)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidDependency, m.Name, err)
+		}
+		have, err := ParseVersion(metas[j].Version)
+		if err != nil || !r.Contains(have) {
+			return fmt.Errorf("%w: %s requires %s %s, found %s", ErrMissingDependency, m.Name, dep.Name, dep.Version, metas[j].Version)
+		}
+	}
+	return nil
+}
+
+// rangeString 返回版本范围的显示形式
+func (d Dependency) rangeString() string {
+	if d.Version == "" {
+		return "(any version)"
+	}
+	return d.Version
+}
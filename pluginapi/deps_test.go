@@ -0,0 +1,212 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Host Version Constraints and Dependencies Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func dep(name, version string) Dependency {
+	return Dependency{Name: name, Version: version}
+}
+
+func TestResolveDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		metas   []PluginMeta
+		want    []string         // 可加载插件的顺序
+		refused map[string]error // 被拒绝的插件与原因
+	}{
+		{
+			name:  "no dependencies sorted by name",
+			metas: []PluginMeta{{Name: "c"}, {Name: "a"}, {Name: "b"}},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name: "dependency first",
+			metas: []PluginMeta{
+				{Name: "app", Dependencies: []Dependency{dep("kafka", "^1.4")}},
+				{Name: "kafka", Version: "1.5.0"},
+			},
+			want: []string{"kafka", "app"},
+		},
+		{
+			name: "diamond",
+			metas: []PluginMeta{
+				{Name: "top", Dependencies: []Dependency{dep("left", ""), dep("right", "")}},
+				{Name: "left", Dependencies: []Dependency{dep("base", "")}},
+				{Name: "right", Dependencies: []Dependency{dep("base", "")}},
+				{Name: "base"},
+			},
+			want: []string{"base", "left", "right", "top"},
+		},
+		{
+			name: "missing dependency",
+			metas: []PluginMeta{
+				{Name: "app", Dependencies: []Dependency{dep("geoip", "")}},
+				{Name: "other"},
+			},
+			want:    []string{"other"},
+			refused: map[string]error{"app": ErrMissingDependency},
+		},
+		{
+			name: "version not satisfied",
+			metas: []PluginMeta{
+				{Name: "app", Dependencies: []Dependency{dep("kafka", ">=2.0.0 <2.3.0")}},
+				{Name: "kafka", Version: "2.3.0"},
+			},
+			want:    []string{"kafka"},
+			refused: map[string]error{"app": ErrMissingDependency},
+		},
+		{
+			name: "unparsable dependency version",
+			metas: []PluginMeta{
+				{Name: "app", Dependencies: []Dependency{dep("kafka", "^1")}},
+				{Name: "kafka", Version: "latest"},
+			},
+			want:    []string{"kafka"},
+			refused: map[string]error{"app": ErrMissingDependency},
+		},
+		{
+			name: "refusal cascades",
+			metas: []PluginMeta{
+				{Name: "a", Dependencies: []Dependency{dep("b", "")}},
+				{Name: "b", Dependencies: []Dependency{dep("c", "")}},
+				{Name: "c", Dependencies: []Dependency{dep("missing", "")}},
+				{Name: "d"},
+			},
+			want:    []string{"d"},
+			refused: map[string]error{"a": ErrMissingDependency, "b": ErrMissingDependency, "c": ErrMissingDependency},
+		},
+		{
+			name: "cycle",
+			metas: []PluginMeta{
+				{Name: "a", Dependencies: []Dependency{dep("b", "")}},
+				{Name: "b", Dependencies: []Dependency{dep("a", "")}},
+				{Name: "ok"},
+			},
+			want:    []string{"ok"},
+			refused: map[string]error{"a": ErrDependencyCycle, "b": ErrDependencyCycle},
+		},
+		{
+			name: "depends on cycle",
+			metas: []PluginMeta{
+				{Name: "a", Dependencies: []Dependency{dep("b", "")}},
+				{Name: "b", Dependencies: []Dependency{dep("a", "")}},
+				{Name: "user", Dependencies: []Dependency{dep("a", "")}},
+			},
+			want:    nil,
+			refused: map[string]error{"a": ErrDependencyCycle, "b": ErrDependencyCycle, "user": ErrDependencyCycle},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := ResolveDependencies(tt.metas)
+			if got := names(ordered); !reflect.DeepEqual(got, tt.want) && !(len(got) == 0 && len(tt.want) == 0) {
+				t.Fatalf("order = %v, want %v", got, tt.want)
+			}
+			if len(tt.refused) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				return
+			}
+			reasons := unwrapAll(err)
+			if len(reasons) != len(tt.refused) {
+				t.Fatalf("got %d refusals (%v), want %d", len(reasons), err, len(tt.refused))
+			}
+			got := make(map[string]error, len(reasons))
+			for _, r := range reasons {
+				var de *DependencyError
+				if !errors.As(r, &de) {
+					t.Fatalf("refusal %v is not a *DependencyError", r)
+				}
+				got[de.Plugin] = r
+			}
+			for name, want := range tt.refused {
+				if !errors.Is(got[name], want) {
+					t.Errorf("%s refused with %v, want %v", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveDependenciesDuplicate(t *testing.T) {
+	ordered, err := ResolveDependencies([]PluginMeta{{Name: "a"}, {Name: "a"}})
+	if ordered != nil || !errors.Is(err, ErrPluginAlreadyExist) {
+		t.Fatalf("got %v, %v; want nil, ErrPluginAlreadyExist", ordered, err)
+	}
+}
+
+func TestCheckHostVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max string
+		host     string
+		err      error
+	}{
+		{"unconstrained", "", "", "anything", nil},
+		{"inside", "2.1.0", "2.9.9", "2.3.0", nil},
+		{"min inclusive", "2.1.0", "", "2.1.0", nil},
+		{"max inclusive", "", "2.9.9", "2.9.9", nil},
+		{"too old", "2.1.0", "", "2.0.9", ErrHostVersionMismatch},
+		{"too new", "", "2.9.9", "3.0.0", ErrHostVersionMismatch},
+		{"prerelease below min", "2.1.0", "", "2.1.0-rc.1", ErrHostVersionMismatch},
+		{"invalid host", "2.1.0", "", "two", ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := PluginMeta{Name: "p", MinHostVersion: tt.min, MaxHostVersion: tt.max}
+			err := m.CheckHostVersion(tt.host)
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		meta PluginMeta
+		err  error
+	}{
+		{"none", PluginMeta{Name: "p"}, nil},
+		{"valid", PluginMeta{Name: "p", MinHostVersion: "2.0", MaxHostVersion: "2.9", Dependencies: []Dependency{dep("a", "^1"), dep("b", "")}}, nil},
+		{"bad min", PluginMeta{Name: "p", MinHostVersion: "2.x"}, ErrInvalidHostVersion},
+		{"min above max", PluginMeta{Name: "p", MinHostVersion: "3.0.0", MaxHostVersion: "2.0.0"}, ErrInvalidHostVersion},
+		{"empty name", PluginMeta{Name: "p", Dependencies: []Dependency{dep("", "")}}, ErrInvalidDependency},
+		{"self", PluginMeta{Name: "p", Dependencies: []Dependency{dep("p", "")}}, ErrInvalidDependency},
+		{"duplicate", PluginMeta{Name: "p", Dependencies: []Dependency{dep("a", ""), dep("a", "^1")}}, ErrInvalidDependency},
+		{"bad range", PluginMeta{Name: "p", Dependencies: []Dependency{dep("a", ">=x")}}, ErrInvalidDependency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meta.validateDependencies()
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// unwrapAll 展开 errors.Join 的结果
+func unwrapAll(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
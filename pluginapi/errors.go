@@ -7,16 +7,63 @@ import "errors"
 
 var (
 	// 元数据校验错误
-	ErrInvalidPluginName  = errors.New("plugin name is empty")
-	ErrMissingSDKVersion  = errors.New("sdk version is missing")
-	ErrSDKVersionMismatch = errors.New("sdk version mismatch between plugin and host")
-	ErrGoVersionMismatch  = errors.New("go version mismatch between plugin and host")
+	ErrInvalidPluginName    = errors.New("plugin name is empty")
+	ErrPluginNameSeparator  = errors.New("plugin name must not contain the instance separator '@'")
+	ErrMissingSDKVersion    = errors.New("sdk version is missing")
+	ErrSDKVersionMismatch   = errors.New("sdk version mismatch between plugin and host")
+	ErrGoVersionMismatch    = errors.New("go version mismatch between plugin and host")
+	ErrInvalidPriority      = errors.New("plugin priority out of range")
+	ErrInvalidOrdering      = errors.New("plugin ordering is inconsistent")
+	ErrOrderCycle           = errors.New("plugin ordering contains a cycle")
+	ErrInvalidHooks         = errors.New("plugin hook mask is invalid")
+	ErrInvalidHookPolicy    = errors.New("plugin hook policy is invalid")
+	ErrInvalidPublishFilter = errors.New("plugin publish filter is invalid")
+	ErrInvalidBatch         = errors.New("plugin batch settings are invalid")
+	ErrInvalidHomepage      = errors.New("plugin homepage is not a valid url")
+	ErrInvalidHostVersion   = errors.New("plugin host version constraint is invalid")
+	ErrInvalidDependency    = errors.New("plugin dependency is invalid")
+	ErrInvalidConfigSchema  = errors.New("plugin config schema is invalid")
+	ErrInvalidInstanceName  = errors.New("plugin instance name is invalid")
 
 	// 加载错误
-	ErrPluginNotFound     = errors.New("plugin file not found")
-	ErrMetaNotFound       = errors.New("plugin meta file not found")
-	ErrSymbolNotFound     = errors.New("plugin does not export 'NewPlugin' symbol")
-	ErrInvalidPluginType  = errors.New("plugin symbol is not of type func() Plugin")
-	ErrPluginInitFailed   = errors.New("plugin initialization failed")
-	ErrPluginAlreadyExist = errors.New("plugin with same name already loaded")
+	ErrPluginNotFound      = errors.New("plugin file not found")
+	ErrMetaNotFound        = errors.New("plugin meta file not found")
+	ErrInvalidMeta         = errors.New("plugin meta file is invalid")
+	ErrPlatformMismatch    = errors.New("plugin was built for a different platform")
+	ErrPluginOpenFailed    = errors.New("failed to open plugin")
+	ErrSymbolNotFound      = errors.New("plugin does not export 'NewPlugin' symbol")
+	ErrInvalidPluginType   = errors.New("plugin symbol is not of type func() Plugin")
+	ErrPluginInitFailed    = errors.New("plugin initialization failed")
+	ErrPluginAlreadyExist  = errors.New("plugin with same name already loaded")
+	ErrNotReconfigurable   = errors.New("plugin does not support live reconfiguration")
+	ErrHostVersionMismatch = errors.New("host version outside the range supported by plugin")
+	ErrMissingDependency   = errors.New("plugin dependency is missing or has an unsupported version")
+	ErrDependencyCycle     = errors.New("plugin dependencies contain a cycle")
+	ErrInvalidConfig       = errors.New("plugin config does not match schema")
+
+	// 热升级错误
+	ErrUpgradeNameMismatch = errors.New("upgrade target has a different plugin name")
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot format")
+
+	// 主程序 API 错误
+	ErrInvalidTopic       = errors.New("invalid topic name")
+	ErrInvalidTopicFilter = errors.New("invalid topic filter")
+	ErrInvalidQoS         = errors.New("invalid qos level")
+	ErrInvalidProperty    = errors.New("invalid mqtt property")
+	ErrClientNotFound     = errors.New("client not found")
+	ErrInvalidSubject     = errors.New("invalid threat subject")
+	ErrInvalidThreatScore = errors.New("threat score must be positive")
+	ErrBanNotFound        = errors.New("ban not found")
+	ErrInvalidMetric      = errors.New("invalid metric")
+	ErrInvalidKey         = errors.New("invalid store key")
+	ErrValueTooLarge      = errors.New("store value too large")
+	ErrInvalidInterval    = errors.New("task interval must be positive")
+	ErrSchedulerClosed    = errors.New("scheduler is closed")
+	ErrInvalidVersion     = errors.New("invalid version")
+
+	// 插件间服务错误
+	ErrServiceNotFound          = errors.New("service not found")
+	ErrInvalidService           = errors.New("invalid service registration")
+	ErrServiceAlreadyRegistered = errors.New("service already registered by another plugin")
+	ErrServiceVersionMismatch   = errors.New("service version does not satisfy requirement")
 )
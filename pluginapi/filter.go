@@ -0,0 +1,71 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Publish Filter
+
+package pluginapi
+
+import (
+	"fmt"
+	"math"
+)
+
+// PublishFilter OnPublish 预过滤条件，在 PluginMeta.PublishFilter 中声明
+//
+// 主程序在调用插件之前判断，只为匹配的消息调用 OnPublish：
+//  1. 主题匹配 Topics 中任一过滤器（空表示全部主题，规则同 MatchTopic）
+//  2. QoS 不低于 MinQoS
+//  3. 按 SampleRate 采样（0 或 1 表示不采样）
+//
+// 采样按每个插件独立计数，SampleRate=0.1 时匹配的消息每 10 条投递 1 条，
+// 插件不应依赖被采样的具体是哪一条。热升级后新实例重新计数。
+type PublishFilter struct {
+	Topics     []string `json:"topics,omitempty"`      // 主题过滤器，如 "audit/#"、"sensor/+/alarm"
+	MinQoS     uint8    `json:"min_qos,omitempty"`     // 最低 QoS（0~2）
+	SampleRate float64  `json:"sample_rate,omitempty"` // 采样率 (0, 1]，0 表示全部投递
+}
+
+// Match 判断消息的主题与 QoS 是否满足过滤条件（不含采样），nil 表示不过滤
+func (f *PublishFilter) Match(topic string, qos uint8) bool {
+	if f == nil {
+		return true
+	}
+	if qos < f.MinQoS {
+		return false
+	}
+	if len(f.Topics) == 0 {
+		return true
+	}
+	for _, filter := range f.Topics {
+		if MatchTopic(filter, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sampled 判断第 n 条（从 0 开始）匹配的消息是否被采样投递
+// 按比例均匀分布：SampleRate=0.25 时投递第 4、8、12... 条匹配的消息
+func (f *PublishFilter) Sampled(n uint64) bool {
+	if f == nil || f.SampleRate <= 0 || f.SampleRate >= 1 {
+		return true
+	}
+	return uint64(float64(n+1)*f.SampleRate) > uint64(float64(n)*f.SampleRate)
+}
+
+// validate 校验过滤条件
+func (f *PublishFilter) validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, filter := range f.Topics {
+		if err := ValidateTopicFilter(filter); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPublishFilter, err)
+		}
+	}
+	if f.MinQoS > 2 {
+		return fmt.Errorf("%w: min_qos %d", ErrInvalidPublishFilter, f.MinQoS)
+	}
+	if math.IsNaN(f.SampleRate) || f.SampleRate < 0 || f.SampleRate > 1 {
+		return fmt.Errorf("%w: sample_rate %v must be between 0 and 1", ErrInvalidPublishFilter, f.SampleRate)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Health Check
+
+package pluginapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// 健康检查参数
+const (
+	HealthCheckInterval = 10 * time.Second // 主程序轮询间隔
+	HealthCheckTimeout  = time.Second      // Health 超时视为 HealthUnhealthy
+)
+
+// HealthMetric 主程序导出健康状态使用的指标名（axmq_plugin_health），插件注册时返回 ErrInvalidMetric
+const HealthMetric = "health"
+
+// HealthStatus 插件健康状态
+type HealthStatus int
+
+const (
+	HealthHealthy   HealthStatus = iota // 正常
+	HealthDegraded                      // 降级：仍正常调用钩子，状态对外暴露并记录日志
+	HealthUnhealthy                     // 不可用：主程序绕过该插件，见 HealthChecker
+)
+
+// String 返回状态名称
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthHealthy:
+		return "healthy"
+	case HealthDegraded:
+		return "degraded"
+	case HealthUnhealthy:
+		return "unhealthy"
+	default:
+		return fmt.Sprintf("HealthStatus(%d)", int(s))
+	}
+}
+
+// Health 健康检查结果
+type Health struct {
+	Status  HealthStatus
+	Message string            // 简要说明，如 "database unreachable"
+	Details map[string]string // 附加信息，如各后端的状态
+}
+
+// HealthChecker 可选接口：插件报告自身及其依赖（数据库、外部服务）的健康状态
+//
+// 主程序每 HealthCheckInterval 调用一次 Health，结果通过指标 HealthMetric
+// （0=healthy, 1=degraded, 2=unhealthy）与管理接口暴露。
+//
+// 状态为 HealthUnhealthy 时主程序绕过该插件，直到恢复：
+//   - OnAuth / OnSubscribe：不调用插件，按该钩子的失败策略处理（默认拒绝，与超时一致，见 HookPolicy）
+//   - OnPublish / OnDisconnect：不调用插件（bypass）
+//
+// 绕过期间插件不会产生错误，因此不会触发熔断。Health 应快速返回缓存的状态，
+// 超过 HealthCheckTimeout 或 panic 视为 HealthUnhealthy。
+type HealthChecker interface {
+	Health() Health
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Hook Mask
+
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HookMask 插件实现的钩子集合
+// 主程序只调用已声明的钩子，未声明的钩子不分发、不复制载荷；
+// 未声明的 OnAuth / OnSubscribe 视为允许（与 BasePlugin 的默认实现一致）
+type HookMask uint32
+
+const (
+	HookAuth       HookMask = 1 << iota // OnAuth
+	HookSubscribe                       // OnSubscribe
+	HookPublish                         // OnPublish
+	HookDisconnect                      // OnDisconnect
+
+	// HookAll 全部钩子（PluginMeta.Hooks 为 0 时的默认值，兼容未声明的插件）
+	HookAll = HookAuth | HookSubscribe | HookPublish | HookDisconnect
+
+	// HookDecisions 可以拒绝请求的钩子
+	HookDecisions = HookAuth | HookSubscribe
+)
+
+var hookNames = []struct {
+	hook HookMask
+	name string
+}{
+	{HookAuth, "auth"},
+	{HookSubscribe, "subscribe"},
+	{HookPublish, "publish"},
+	{HookDisconnect, "disconnect"},
+}
+
+// Has 是否包含 h 中的全部钩子
+func (m HookMask) Has(h HookMask) bool {
+	return m&h == h
+}
+
+// String 返回 "auth|publish" 形式的名称
+func (m HookMask) String() string {
+	if m == 0 {
+		return "none"
+	}
+	var names []string
+	for _, hn := range hookNames {
+		if m&hn.hook != 0 {
+			names = append(names, hn.name)
+		}
+	}
+	if rest := m &^ HookAll; rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(rest)))
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalText 以名称形式写入 .meta.json
+func (m HookMask) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText 解析 "auth|publish" 形式的名称
+func (m *HookMask) UnmarshalText(text []byte) error {
+	mask, err := ParseHookMask(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mask
+	return nil
+}
+
+// ParseHookMask 解析以 '|' 或 ',' 分隔的钩子名称，"none" 或空字符串为 0
+func ParseHookMask(s string) (HookMask, error) {
+	var mask HookMask
+	for _, name := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' }) {
+		name = strings.TrimSpace(name)
+		if name == "none" {
+			continue
+		}
+		found := false
+		for _, hn := range hookNames {
+			if hn.name == name {
+				mask |= hn.hook
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("%w: unknown hook %q", ErrInvalidHooks, name)
+		}
+	}
+	return mask, nil
+}
+
+// FailurePolicy 钩子失败（超时、panic、健康检查不可用）时的处理方式
+type FailurePolicy string
+
+const (
+	FailClosed FailurePolicy = "closed" // 拒绝请求（OnAuth / OnSubscribe 的默认值）
+	FailOpen   FailurePolicy = "open"   // 忽略该插件，按允许处理（OnPublish / OnDisconnect 固定为跳过）
+)
+
+// HookPolicy 单个钩子的超时与失败策略
+type HookPolicy struct {
+	Timeout   time.Duration `json:"timeout,omitempty"`    // 超时时间（0 表示使用 HookTimeout，最大 MaxHookTimeout）
+	OnFailure FailurePolicy `json:"on_failure,omitempty"` // 失败策略（空表示默认；只对 OnAuth / OnSubscribe 有效）
+}
+
+// HookPolicies 按钩子设置的策略，键为单个钩子（如 HookAuth）
+// 在 .meta.json 中写作 {"auth": {"timeout": ..., "on_failure": "open"}}
+type HookPolicies map[HookMask]HookPolicy
+
+// validate 校验策略
+func (p HookPolicies) validate() error {
+	for hook, policy := range p {
+		if hook&^HookAll != 0 || hook&(hook-1) != 0 || hook == 0 {
+			return fmt.Errorf("%w: %s is not a single hook", ErrInvalidHookPolicy, hook)
+		}
+		if policy.Timeout < 0 || policy.Timeout > MaxHookTimeout {
+			return fmt.Errorf("%w: %s timeout %v must be between 0 and %v", ErrInvalidHookPolicy, hook, policy.Timeout, MaxHookTimeout)
+		}
+		switch policy.OnFailure {
+		case "":
+		case FailOpen, FailClosed:
+			if !HookDecisions.Has(hook) {
+				return fmt.Errorf("%w: %s cannot deny events, on_failure only applies to auth and subscribe", ErrInvalidHookPolicy, hook)
+			}
+		default:
+			return fmt.Errorf("%w: %s on_failure %q", ErrInvalidHookPolicy, hook, policy.OnFailure)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Host API
+
+package pluginapi
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// HostAPI 主程序提供给插件的能力接口
+// 通过 HostInitializer.InitWithHost 在初始化时传入，插件可保存供后续钩子使用
+// 所有方法均为并发安全，可在任意 goroutine 中调用
+type HostAPI interface {
+	// Publish 以主程序身份发布一条消息
+	// 消息按正常流程分发给订阅者，但不会触发任何插件的 OnPublish（避免循环）
+	Publish(msg *Message) error
+
+	// Clients 客户端与会话管理
+	Clients() ClientManager
+
+	// Security 威胁计分与封禁管理
+	Security() SecurityManager
+
+	// Logger 插件专用的结构化日志
+	// 已预置 "plugin" 属性（命名实例另有 "instance" 属性），输出进入 AXMQ 日志系统，级别由插件配置中的 log_level 控制
+	Logger() *slog.Logger
+
+	// Instance 当前实例名，默认实例返回空字符串，见 instance.go
+	// 同一 .so 的多个实例共享包级变量，需要区分实例的状态应放在插件结构体中
+	Instance() string
+
+	// Metrics 插件专用的指标注册表，由主程序统一导出
+	Metrics() Metrics
+
+	// Store 插件专用的持久化键值存储，热加载与重启后数据保留
+	Store() KVStore
+
+	// Scheduler 主程序托管的定时器与后台任务，插件关闭前自动取消
+	Scheduler() Scheduler
+
+	// Services 插件间服务注册表
+	Services() ServiceRegistry
+
+	// Cluster 集群拓扑信息（本节点 ID、成员、leader）
+	Cluster() Cluster
+
+	// Broker 保留消息、主题树、订阅数与 $SYS 统计的只读查询
+	Broker() BrokerState
+}
+
+// HostInitializer 可选接口：需要主程序能力的插件实现此接口
+// 实现后主程序调用 InitWithHost 代替 Init，Init 不会再被调用
+type HostInitializer interface {
+	InitWithHost(host HostAPI, config []byte) error
+}
+
+// Message 插件发布的消息
+type Message struct {
+	Topic      string             // 发布主题（不能包含通配符）
+	Payload    []byte             // 消息内容（调用后主程序持有，插件不应再修改）
+	QoS        uint8              // QoS 等级（0/1/2）
+	Retain     bool               // 是否为保留消息
+	Properties *PublishProperties // MQTT 5 属性（可为 nil）
+}
+
+// PublishProperties MQTT 5 发布属性
+// 对 MQTT 3.x 订阅者分发时将被忽略
+type PublishProperties struct {
+	PayloadFormat   uint8          // 载荷格式（0=字节流，1=UTF-8）
+	MessageExpiry   uint32         // 消息过期时间（秒，0 表示不过期）
+	ContentType     string         // 内容类型
+	ResponseTopic   string         // 响应主题
+	CorrelationData []byte         // 对比数据
+	UserProperties  []UserProperty // 用户属性
+}
+
+// UserProperty MQTT 5 用户属性
+type UserProperty struct {
+	Key   string
+	Value string
+}
+
+// Validate 校验消息
+func (m *Message) Validate() error {
+	if m.Topic == "" || strings.ContainsAny(m.Topic, "+#") {
+		return fmt.Errorf("%w: %q", ErrInvalidTopic, m.Topic)
+	}
+	if m.QoS > 2 {
+		return fmt.Errorf("%w: %d", ErrInvalidQoS, m.QoS)
+	}
+	if m.Properties != nil && m.Properties.PayloadFormat > 1 {
+		return fmt.Errorf("%w: payload format %d", ErrInvalidProperty, m.Properties.PayloadFormat)
+	}
+	return nil
+}
+
+// ReasonCode MQTT 5 断开原因码
+// 对 MQTT 3.x 客户端直接关闭连接，原因码仅用于日志
+type ReasonCode uint8
+
+// 常用断开原因码
+const (
+	ReasonNormalDisconnection  ReasonCode = 0x00 // 正常断开
+	ReasonUnspecifiedError     ReasonCode = 0x80 // 未指明的错误
+	ReasonNotAuthorized        ReasonCode = 0x87 // 未授权
+	ReasonServerBusy           ReasonCode = 0x89 // 服务端繁忙
+	ReasonSessionTakenOver     ReasonCode = 0x8E // 会话被接管
+	ReasonQuotaExceeded        ReasonCode = 0x97 // 超出配额
+	ReasonAdministrativeAction ReasonCode = 0x98 // 管理操作
+)
+
+// DisconnectReasonKicked 被 ClientManager.Disconnect 踢下线时
+// OnDisconnect 收到的 DisconnectContext.Reason
+const DisconnectReasonKicked = "kicked"
+
+// ClientManager 客户端与会话管理
+type ClientManager interface {
+	// Disconnect 断开指定客户端
+	// 断开后异步触发 OnDisconnect（Reason 为 "kicked"），客户端不存在时返回 ErrClientNotFound
+	Disconnect(clientID string, reason ReasonCode) error
+
+	// Lookup 查询客户端信息，客户端未知时 ok=false
+	// 离线但保留会话的客户端返回 Online=false
+	Lookup(clientID string) (info ClientInfo, ok bool)
+
+	// Subscriptions 列出客户端的订阅
+	Subscriptions(clientID string) ([]Subscription, error)
+
+	// Subscribe 代替客户端订阅主题（不会触发 OnSubscribe）
+	Subscribe(clientID string, subs ...Subscription) error
+
+	// Unsubscribe 代替客户端取消订阅，未订阅的主题忽略
+	Unsubscribe(clientID string, topics ...string) error
+}
+
+// ClientInfo 客户端信息
+type ClientInfo struct {
+	ClientID    string    // 客户端 ID
+	Username    string    // 用户名
+	IP          string    // 客户端 IP 地址
+	Online      bool      // 是否在线
+	ConnectedAt time.Time // 最近一次连接时间
+}
+
+// Subscription 订阅信息
+type Subscription struct {
+	Topic string // 订阅主题（可包含通配符）
+	QoS   uint8  // QoS 等级
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Instances
+
+package pluginapi
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// InstanceSeparator 插件名与实例名之间的分隔符，如 "webhook@billing"
+const InstanceSeparator = "@"
+
+// 命名实例
+//
+// 同一个 .so 可以按不同配置加载多次。插件目录中与 .so 同名、带 "@<实例名>" 后缀的配置文件
+// 各对应一个实例：
+//
+//	webhook.so
+//	webhook@billing.yml   -> 实例 webhook@billing
+//	webhook@audit.yml     -> 实例 webhook@audit
+//
+// 每个实例单独调用一次 NewPlugin()，拥有各自的配置、日志、指标、存储命名空间与托管任务；
+// 插件不需要做任何改动，但不应把状态放在包级变量中（所有实例共享同一个包）。
+//
+// 实例的完整名称 "<插件名>@<实例名>" 用于区分实例：日志带 "instance" 属性，指标带 plugin_instance 标签，
+// 存储命名空间、服务提供者、热升级快照均按完整名称隔离。元信息、依赖与执行顺序仍按插件名计算，
+// 同一插件的所有实例在执行顺序中相邻。覆盖 .so 时该插件的所有实例依次热升级。
+//
+// 没有实例名的配置文件（webhook.yml）对应默认实例，完整名称即插件名，与之前的行为一致。
+// 插件名不能包含 "@"（ErrPluginNameSeparator），否则插件 "a@b" 与插件 a 的实例 b 无法区分。
+
+var instanceNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// ValidateInstanceName 校验实例名：字母或数字开头，只包含字母、数字、下划线与连字符，最长 64 字节
+// 空字符串表示默认实例，视为有效
+func ValidateInstanceName(instance string) error {
+	if instance == "" {
+		return nil
+	}
+	if len(instance) > 64 || !instanceNameRe.MatchString(instance) {
+		return fmt.Errorf("%w: %q", ErrInvalidInstanceName, instance)
+	}
+	return nil
+}
+
+// InstanceName 返回实例的完整名称，默认实例返回插件名
+func InstanceName(plugin, instance string) string {
+	if instance == "" {
+		return plugin
+	}
+	return plugin + InstanceSeparator + instance
+}
+
+// ParseInstanceName 拆分完整名称 "<插件名>@<实例名>"，没有分隔符时 instance 为空
+func ParseInstanceName(name string) (plugin, instance string, err error) {
+	plugin, instance, _ = strings.Cut(name, InstanceSeparator)
+	if plugin == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidPluginName, name)
+	}
+	if err := ValidateInstanceName(instance); err != nil {
+		return "", "", err
+	}
+	if strings.Contains(name, InstanceSeparator) && instance == "" {
+		return "", "", fmt.Errorf("%w: empty instance in %q", ErrInvalidInstanceName, name)
+	}
+	return plugin, instance, nil
+}
+
+// ConfigFileInstance 从配置文件路径解析所属的插件文件名与实例名
+// 如 "plugins/webhook@billing.yml" 返回 ("webhook", "billing")，"plugins/webhook.yml" 返回 ("webhook", "")
+// base 为不含扩展名的 .so 文件名（而不是 PluginMeta.Name）
+func ConfigFileInstance(path string) (base, instance string, err error) {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return ParseInstanceName(name)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Logger
+
+package pluginapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+)
+
+// 主程序为插件日志预置的属性名
+const (
+	LogKeyPlugin   = "plugin"   // 插件名称
+	LogKeyInstance = "instance" // 实例名（仅命名实例，见 instance.go）
+)
+
+// ConfigKeyLogLevel 插件配置中控制日志级别的保留字段
+// 取值：debug / info / warn / error，缺省为 info
+const ConfigKeyLogLevel = "log_level"
+
+// LogLevelFromConfig 从插件配置中读取日志级别
+// 支持 JSON 对象与 YAML 的顶层 "log_level" 字段，未设置或无法识别时返回 ok=false
+func LogLevelFromConfig(config []byte) (level slog.Level, ok bool) {
+	config = bytes.TrimSpace(config)
+	if len(config) == 0 {
+		return slog.LevelInfo, false
+	}
+
+	var raw string
+	if config[0] == '{' {
+		var cfg map[string]json.RawMessage
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return slog.LevelInfo, false
+		}
+		if v, exists := cfg[ConfigKeyLogLevel]; !exists || json.Unmarshal(v, &raw) != nil {
+			return slog.LevelInfo, false
+		}
+	} else {
+		raw, ok = yamlTopLevelValue(config, ConfigKeyLogLevel)
+		if !ok {
+			return slog.LevelInfo, false
+		}
+	}
+
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return slog.LevelInfo, false
+	}
+	return level, true
+}
+
+// yamlTopLevelValue 读取 YAML 顶层的标量字段（仅处理 "key: value" 形式）
+func yamlTopLevelValue(data []byte, key string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, key+":") {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, key+":"))
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return strings.Trim(value, `"'`), value != ""
+	}
+	return "", false
+}
@@ -3,7 +3,12 @@
 
 package pluginapi
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // 默认超时配置
 const (
@@ -14,12 +19,36 @@ const (
 // PluginMeta 插件元信息
 // 用于加载时校验插件与主程序的兼容性
 type PluginMeta struct {
-	Name        string        `json:"name"`                   // 插件名称
-	Version     string        `json:"version"`                // 插件版本
-	SDKVersion  string        `json:"sdk_version"`            // SDK 版本（必须与主程序一致）
-	GoVersion   string        `json:"go_version"`             // 编译时的 Go 版本
-	BuildTime   string        `json:"build_time"`             // 构建时间 (RFC3339)
-	HookTimeout time.Duration `json:"hook_timeout,omitempty"` // 钩子超时时间（0 表示使用默认 100ms）
+	Name          string          `json:"name"`                     // 插件名称（不能包含 "@"，见 instance.go）
+	Version       string          `json:"version"`                  // 插件版本
+	SDKVersion    string          `json:"sdk_version"`              // SDK 版本（主版本相同且次版本不高于主程序，见 CheckSDKVersion）
+	GoVersion     string          `json:"go_version"`               // 编译时的 Go 版本（非空时必须与主程序一致）
+	BuildTime     string          `json:"build_time"`               // 构建时间 (RFC3339)
+	HookTimeout   time.Duration   `json:"hook_timeout,omitempty"`   // 钩子超时时间（0 表示使用默认 100ms）
+	DrainTimeout  time.Duration   `json:"drain_timeout,omitempty"`  // 排空期限（0 表示使用默认 5s，见 Drainer）
+	Hooks         HookMask        `json:"hooks,omitempty"`          // 实现的钩子（0 表示全部；构建工具可自动检测，见 hooks.go）
+	HookPolicies  HookPolicies    `json:"hook_policies,omitempty"`  // 按钩子覆盖超时与失败策略（可选，见 hooks.go）
+	PublishFilter *PublishFilter  `json:"publish_filter,omitempty"` // OnPublish 预过滤：主题、最低 QoS、采样率（可选，见 filter.go）
+	SharedPublish bool            `json:"shared_publish,omitempty"` // OnPublish 接收共享的池化上下文，返回后不再引用（可选，见 context.go）
+	BatchSize     int             `json:"batch_size,omitempty"`     // 每批最多消息数（0 表示默认 100，最大 10000，仅 BatchPublisher，见 batch.go）
+	BatchLinger   time.Duration   `json:"batch_linger,omitempty"`   // 攒批等待时间（0 表示默认 10ms，最大 5s，仅 BatchPublisher）
+	ConfigSchema  json.RawMessage `json:"config_schema,omitempty"`  // 配置的 JSON Schema，主程序在 Init 之前校验（可选，见 config.go）
+
+	// 描述信息（可选，供运维展示）
+	Description string `json:"description,omitempty"` // 一句话说明插件用途
+	Author      string `json:"author,omitempty"`      // 作者或维护团队，如 "Ops Team <ops@example.com>"
+	License     string `json:"license,omitempty"`     // 许可证（SPDX 标识，如 "Apache-2.0"）
+	Homepage    string `json:"homepage,omitempty"`    // 主页或文档地址（http/https）
+
+	// 依赖（可选，见 deps.go）
+	MinHostVersion string       `json:"min_host_version,omitempty"` // 支持的最低 AXMQ 版本（包含）
+	MaxHostVersion string       `json:"max_host_version,omitempty"` // 支持的最高 AXMQ 版本（包含）
+	Dependencies   []Dependency `json:"dependencies,omitempty"`     // 依赖的其他插件及版本范围
+
+	// 执行顺序（可选，设置后 OnAuth/OnSubscribe 改为顺序执行，见 order.go）
+	Priority int      `json:"priority,omitempty"` // 优先级，数值越大越先执行（范围 -1000~1000，默认 0）
+	After    []string `json:"after,omitempty"`    // 必须在这些插件之后执行（插件名，未加载的插件忽略）
+	Final    bool     `json:"final,omitempty"`    // 本插件允许即为最终结果，跳过后续插件
 }
 
 // GetHookTimeout 获取有效的超时时间
@@ -33,16 +62,105 @@ func (m *PluginMeta) GetHookTimeout() time.Duration {
 	return m.HookTimeout
 }
 
+// GetHookPolicy 获取钩子的有效策略：超时未设置时使用 GetHookTimeout，
+// 失败策略未设置时 OnAuth / OnSubscribe 为 FailClosed；OnPublish / OnDisconnect 固定为 FailOpen（跳过）
+func (m *PluginMeta) GetHookPolicy(hook HookMask) HookPolicy {
+	p := m.HookPolicies[hook]
+	if p.Timeout <= 0 {
+		p.Timeout = m.GetHookTimeout()
+	}
+	if p.Timeout > MaxHookTimeout {
+		p.Timeout = MaxHookTimeout
+	}
+	switch {
+	case !HookDecisions.Has(hook):
+		p.OnFailure = FailOpen
+	case p.OnFailure == "":
+		p.OnFailure = FailClosed
+	}
+	return p
+}
+
+// GetDrainTimeout 获取有效的排空期限
+func (m *PluginMeta) GetDrainTimeout() time.Duration {
+	if m.DrainTimeout <= 0 {
+		return DefaultDrainTimeout
+	}
+	if m.DrainTimeout > MaxDrainTimeout {
+		return MaxDrainTimeout
+	}
+	return m.DrainTimeout
+}
+
+// GetBatchSize 获取有效的批大小
+func (m *PluginMeta) GetBatchSize() int {
+	if m.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	if m.BatchSize > MaxBatchSize {
+		return MaxBatchSize
+	}
+	return m.BatchSize
+}
+
+// GetBatchLinger 获取有效的攒批等待时间
+func (m *PluginMeta) GetBatchLinger() time.Duration {
+	if m.BatchLinger <= 0 {
+		return DefaultBatchLinger
+	}
+	if m.BatchLinger > MaxBatchLinger {
+		return MaxBatchLinger
+	}
+	return m.BatchLinger
+}
+
+// GetHooks 获取有效的钩子集合
+func (m *PluginMeta) GetHooks() HookMask {
+	if m.Hooks == 0 {
+		return HookAll
+	}
+	return m.Hooks
+}
+
 // Validate 校验元信息
 func (m *PluginMeta) Validate() error {
 	if m.Name == "" {
 		return ErrInvalidPluginName
 	}
-	if m.SDKVersion == "" {
-		return ErrMissingSDKVersion
+	if strings.Contains(m.Name, InstanceSeparator) {
+		return fmt.Errorf("%w: %q", ErrPluginNameSeparator, m.Name)
+	}
+	if err := CheckSDKVersion(m.SDKVersion); err != nil {
+		return err
+	}
+	if err := CheckGoVersion(m.GoVersion); err != nil {
+		return err
+	}
+	if m.Hooks&^HookAll != 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidHooks, m.Hooks)
+	}
+	if err := m.HookPolicies.validate(); err != nil {
+		return err
+	}
+	if err := m.PublishFilter.validate(); err != nil {
+		return err
+	}
+	if err := m.validateBatch(); err != nil {
+		return err
+	}
+	if err := m.validateDescriptive(); err != nil {
+		return err
+	}
+	if err := m.validateDependencies(); err != nil {
+		return err
+	}
+	if len(m.ConfigSchema) > 0 {
+		if err := CheckConfigSchema(m.ConfigSchema); err != nil {
+			return err
+		}
 	}
-	if m.SDKVersion != SDKVersion {
-		return ErrSDKVersionMismatch
+	if err := m.validateOrder(); err != nil {
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,35 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Metadata Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"webhook", nil},
+		{"my-plugin_2", nil},
+		{"", ErrInvalidPluginName},
+		{"a@b", ErrPluginNameSeparator},
+		{"webhook@", ErrPluginNameSeparator},
+		{"@billing", ErrPluginNameSeparator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := PluginMeta{Name: tt.name, Version: "1.0.0", SDKVersion: SDKVersion}
+			err := m.Validate()
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Metrics
+
+package pluginapi
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MetricPrefix 主程序为插件指标名称添加的前缀，避免与 AXMQ 内置指标冲突
+const MetricPrefix = "axmq_plugin_"
+
+// 主程序为插件指标预置的标签名（插件不能使用）
+const (
+	MetricLabelPlugin   = "plugin"          // 插件名称
+	MetricLabelInstance = "plugin_instance" // 实例名（仅命名实例，见 instance.go）；不使用 instance，以免与 Prometheus 的抓取目标标签冲突
+)
+
+// DefaultBuckets 直方图默认分桶（与 Prometheus 客户端一致，单位通常为秒）
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Labels 指标标签
+type Labels map[string]string
+
+// Counter 单调递增计数器
+type Counter interface {
+	Inc()
+	Add(delta float64) // delta 必须 >= 0，负数将被忽略
+	Value() float64
+}
+
+// Gauge 可增可减的测量值
+type Gauge interface {
+	Set(v float64)
+	Inc()
+	Dec()
+	Add(delta float64)
+	Value() float64
+}
+
+// Histogram 直方图
+type Histogram interface {
+	Observe(v float64)
+}
+
+// Metrics 主程序提供的指标注册表
+// 指标名称自动添加 "axmq_plugin_" 前缀，并附带 plugin=<插件名> 标签，命名实例另附带 plugin_instance=<实例名> 标签
+// 相同名称与标签重复获取返回同一实例；同一插件内同名指标帮助信息或分桶不一致时返回 ErrInvalidMetric
+// 不同插件可以使用相同的指标名（以 plugin 标签区分，导出第一个注册者的帮助信息），但类型必须一致
+type Metrics interface {
+	Counter(name, help string, labels Labels) (Counter, error)
+	Gauge(name, help string, labels Labels) (Gauge, error)
+	Histogram(name, help string, buckets []float64, labels Labels) (Histogram, error)
+}
+
+var metricNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+var labelNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateMetric 校验插件注册的指标名称与标签
+func ValidateMetric(name string, labels Labels) error {
+	if !metricNameRe.MatchString(name) {
+		return fmt.Errorf("%w: invalid name %q", ErrInvalidMetric, name)
+	}
+	if IsReservedMetric(name) {
+		return fmt.Errorf("%w: name %q is reserved", ErrInvalidMetric, name)
+	}
+	return validateLabels(labels)
+}
+
+// IsReservedMetric 是否为主程序自用的指标名（如 HealthMetric），插件注册时返回 ErrInvalidMetric
+func IsReservedMetric(name string) bool {
+	return name == HealthMetric
+}
+
+// validateLabels 校验标签名，插件不能使用主程序预置的标签
+func validateLabels(labels Labels) error {
+	for k := range labels {
+		if !labelNameRe.MatchString(k) || k == "le" || len(k) >= 2 && k[:2] == "__" {
+			return fmt.Errorf("%w: invalid label %q", ErrInvalidMetric, k)
+		}
+		if k == MetricLabelPlugin || k == MetricLabelInstance {
+			return fmt.Errorf("%w: label %q is reserved", ErrInvalidMetric, k)
+		}
+	}
+	return nil
+}
+
+// ValidateBuckets 校验直方图分桶（必须严格递增）
+func ValidateBuckets(buckets []float64) error {
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return fmt.Errorf("%w: buckets must be strictly increasing", ErrInvalidMetric)
+		}
+	}
+	return nil
+}
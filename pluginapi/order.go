@@ -0,0 +1,195 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Ordering
+
+package pluginapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 优先级范围
+const (
+	MinPriority = -1000
+	MaxPriority = 1000
+)
+
+// 执行模型
+//
+// 默认情况下（所有插件均未设置 Priority/After/Final），多插件并行执行，
+// 任一插件拒绝即拒绝，取最慢者耗时。
+//
+// 只要有一个已加载插件设置了 Priority、After 或 Final，OnAuth/OnSubscribe
+// 改为按 SortPlugins 的结果顺序执行：
+//   - 插件拒绝：立即终止，结果为拒绝
+//   - Final 插件允许：立即终止，结果为允许（后续插件不再调用）
+//   - 非 Final 插件允许：继续调用下一个插件，全部允许才为允许
+//
+// OnPublish/OnDisconnect 为通知型钩子，始终并行执行，不受排序影响。
+
+// IsOrdered 是否设置了排序相关字段
+func (m *PluginMeta) IsOrdered() bool {
+	return m.Priority != 0 || len(m.After) > 0 || m.Final
+}
+
+// validateOrder 校验单个插件的排序字段
+func (m *PluginMeta) validateOrder() error {
+	if m.Priority < MinPriority || m.Priority > MaxPriority {
+		return fmt.Errorf("%w: %d (allowed %d~%d)", ErrInvalidPriority, m.Priority, MinPriority, MaxPriority)
+	}
+	seen := make(map[string]bool, len(m.After))
+	for _, name := range m.After {
+		if name == "" {
+			return fmt.Errorf("%w: empty plugin name in after", ErrInvalidOrdering)
+		}
+		if name == m.Name {
+			return fmt.Errorf("%w: plugin %q cannot run after itself", ErrInvalidOrdering, m.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: duplicate %q in after", ErrInvalidOrdering, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// Sequential 判断一组插件是否需要顺序执行
+func Sequential(metas []PluginMeta) bool {
+	for i := range metas {
+		if metas[i].IsOrdered() {
+			return true
+		}
+	}
+	return false
+}
+
+// SortPlugins 计算插件执行顺序
+// 先满足 After 约束，再按 Priority 从高到低，最后按名称排序保证结果稳定
+// After 中引用的未加载插件将被忽略；存在环时返回 *OrderCycleError（包装 ErrOrderCycle）
+func SortPlugins(metas []PluginMeta) ([]PluginMeta, error) {
+	index := make(map[string]int, len(metas))
+	for i := range metas {
+		if _, ok := index[metas[i].Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrPluginAlreadyExist, metas[i].Name)
+		}
+		index[metas[i].Name] = i
+	}
+
+	// indegree[i] = 插件 i 需要等待的插件数
+	indegree := make([]int, len(metas))
+	next := make([][]int, len(metas))
+	for i := range metas {
+		for _, dep := range metas[i].After {
+			j, ok := index[dep]
+			if !ok {
+				continue
+			}
+			indegree[i]++
+			next[j] = append(next[j], i)
+		}
+	}
+
+	var ready []int
+	for i := range metas {
+		if indegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sorted := make([]PluginMeta, 0, len(metas))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool {
+			ma, mb := &metas[ready[a]], &metas[ready[b]]
+			if ma.Priority != mb.Priority {
+				return ma.Priority > mb.Priority
+			}
+			return ma.Name < mb.Name
+		})
+		cur := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, metas[cur])
+		for _, n := range next[cur] {
+			indegree[n]--
+			if indegree[n] == 0 {
+				ready = append(ready, n)
+			}
+		}
+	}
+
+	if len(sorted) != len(metas) {
+		return nil, &OrderCycleError{Plugins: cycleMembers(metas, next, indegree)}
+	}
+	return sorted, nil
+}
+
+// OrderCycleError After 形成环时 SortPlugins 返回的错误，可用 errors.As 取得环中的插件
+type OrderCycleError struct {
+	Plugins []string // 处于环中的插件名称（已排序），只排在环之后的插件不包含在内
+}
+
+func (e *OrderCycleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrOrderCycle, strings.Join(e.Plugins, ", "))
+}
+
+func (e *OrderCycleError) Unwrap() error {
+	return ErrOrderCycle
+}
+
+// cycleMembers 在拓扑排序剩余的插件（indegree > 0）中查找强连通分量（Tarjan），返回处于环中的插件名称
+func cycleMembers(metas []PluginMeta, next [][]int, indegree []int) []string {
+	index := make([]int, len(metas)) // 访问序号，-1 表示未访问
+	low := make([]int, len(metas))
+	onStack := make([]bool, len(metas))
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	var cycle []string
+	counter := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		index[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range next[v] {
+			if indegree[w] == 0 {
+				continue
+			}
+			if index[w] < 0 {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var component []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		// validateOrder 已拒绝 After 自身，单个插件不会成环
+		if len(component) > 1 {
+			for _, w := range component {
+				cycle = append(cycle, metas[w].Name)
+			}
+		}
+	}
+	for i := range metas {
+		if indegree[i] > 0 && index[i] < 0 {
+			visit(i)
+		}
+	}
+	sort.Strings(cycle)
+	return cycle
+}
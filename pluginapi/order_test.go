@@ -0,0 +1,192 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Ordering Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func names(metas []PluginMeta) []string {
+	out := make([]string, len(metas))
+	for i := range metas {
+		out[i] = metas[i].Name
+	}
+	return out
+}
+
+func TestSortPlugins(t *testing.T) {
+	tests := []struct {
+		name  string
+		metas []PluginMeta
+		want  []string
+		err   error
+	}{
+		{
+			name:  "empty",
+			metas: nil,
+			want:  []string{},
+		},
+		{
+			name:  "name tie-break",
+			metas: []PluginMeta{{Name: "c"}, {Name: "a"}, {Name: "b"}},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "priority high first",
+			metas: []PluginMeta{{Name: "a", Priority: -5}, {Name: "b"}, {Name: "c", Priority: 10}},
+			want:  []string{"c", "b", "a"},
+		},
+		{
+			name:  "equal priority by name",
+			metas: []PluginMeta{{Name: "z", Priority: 3}, {Name: "y", Priority: 3}, {Name: "x"}},
+			want:  []string{"y", "z", "x"},
+		},
+		{
+			name:  "after overrides priority",
+			metas: []PluginMeta{{Name: "a", Priority: 100, After: []string{"b"}}, {Name: "b", Priority: -100}},
+			want:  []string{"b", "a"},
+		},
+		{
+			name: "after chain",
+			metas: []PluginMeta{
+				{Name: "a", After: []string{"b"}},
+				{Name: "b", After: []string{"c"}},
+				{Name: "c"},
+			},
+			want: []string{"c", "b", "a"},
+		},
+		{
+			name: "priority among ready plugins",
+			metas: []PluginMeta{
+				{Name: "base"},
+				{Name: "low", Priority: 1, After: []string{"base"}},
+				{Name: "high", Priority: 9, After: []string{"base"}},
+				{Name: "free", Priority: 5},
+			},
+			want: []string{"free", "base", "high", "low"},
+		},
+		{
+			name:  "after unknown plugin ignored",
+			metas: []PluginMeta{{Name: "a", After: []string{"missing"}}, {Name: "b", Priority: -1}},
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "duplicate name",
+			metas: []PluginMeta{{Name: "a"}, {Name: "a"}},
+			err:   ErrPluginAlreadyExist,
+		},
+		{
+			name:  "two-node cycle",
+			metas: []PluginMeta{{Name: "a", After: []string{"b"}}, {Name: "b", After: []string{"a"}}},
+			err:   ErrOrderCycle,
+		},
+		{
+			name: "cycle behind independent plugin",
+			metas: []PluginMeta{
+				{Name: "ok"},
+				{Name: "a", After: []string{"c"}},
+				{Name: "b", After: []string{"a"}},
+				{Name: "c", After: []string{"b"}},
+			},
+			err: ErrOrderCycle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SortPlugins(tt.metas)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !reflect.DeepEqual(names(got), tt.want) {
+				t.Fatalf("order = %v, want %v", names(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSortPluginsCycleMembers(t *testing.T) {
+	_, err := SortPlugins([]PluginMeta{
+		{Name: "ok"},
+		{Name: "b", After: []string{"a"}},
+		{Name: "a", After: []string{"b"}},
+	})
+	if err == nil || err.Error() != ErrOrderCycle.Error()+": a, b" {
+		t.Fatalf("err = %v, want cycle listing a, b", err)
+	}
+
+	// 排在环之后、或位于两个环之间的插件不属于环
+	_, err = SortPlugins([]PluginMeta{
+		{Name: "a", After: []string{"b"}},
+		{Name: "b", After: []string{"a"}},
+		{Name: "bridge", After: []string{"a"}},
+		{Name: "x", After: []string{"bridge", "y"}},
+		{Name: "y", After: []string{"x"}},
+		{Name: "tail", After: []string{"y"}},
+	})
+	var ce *OrderCycleError
+	if !errors.As(err, &ce) || !errors.Is(err, ErrOrderCycle) {
+		t.Fatalf("err = %v, want *OrderCycleError", err)
+	}
+	if want := []string{"a", "b", "x", "y"}; !reflect.DeepEqual(ce.Plugins, want) {
+		t.Fatalf("cycle = %v, want %v", ce.Plugins, want)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		meta PluginMeta
+		err  error
+	}{
+		{"zero", PluginMeta{Name: "p"}, nil},
+		{"min priority", PluginMeta{Name: "p", Priority: MinPriority}, nil},
+		{"max priority", PluginMeta{Name: "p", Priority: MaxPriority}, nil},
+		{"below min", PluginMeta{Name: "p", Priority: MinPriority - 1}, ErrInvalidPriority},
+		{"above max", PluginMeta{Name: "p", Priority: MaxPriority + 1}, ErrInvalidPriority},
+		{"after others", PluginMeta{Name: "p", After: []string{"a", "b"}}, nil},
+		{"after empty", PluginMeta{Name: "p", After: []string{""}}, ErrInvalidOrdering},
+		{"after self", PluginMeta{Name: "p", After: []string{"p"}}, ErrInvalidOrdering},
+		{"after duplicate", PluginMeta{Name: "p", After: []string{"a", "a"}}, ErrInvalidOrdering},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meta.validateOrder()
+			if tt.err == nil && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSequential(t *testing.T) {
+	tests := []struct {
+		name  string
+		metas []PluginMeta
+		want  bool
+	}{
+		{"none", nil, false},
+		{"unordered", []PluginMeta{{Name: "a"}, {Name: "b"}}, false},
+		{"priority", []PluginMeta{{Name: "a"}, {Name: "b", Priority: -1}}, true},
+		{"after", []PluginMeta{{Name: "a", After: []string{"b"}}}, true},
+		{"final", []PluginMeta{{Name: "a"}, {Name: "b", Final: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sequential(tt.metas); got != tt.want {
+				t.Fatalf("Sequential = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
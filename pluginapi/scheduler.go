@@ -0,0 +1,46 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Host-managed Tasks
+
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+// TaskStopTimeout 插件关闭时等待任务退出的最长时间
+const TaskStopTimeout = 5 * time.Second
+
+// Scheduler 主程序托管的定时器与后台任务
+//
+// Go 插件无法卸载，插件自行启动且忘记停止的 goroutine 在热加载后会永远运行。
+// 通过 Scheduler 启动的任务由主程序跟踪，关闭流程如下：
+//  1. 实现了 Drainer 的插件先排空，期间任务仍在运行
+//  2. 取消所有任务的 ctx
+//  3. 等待正在执行的回调返回（最长 TaskStopTimeout），超时的任务记录为泄漏
+//  4. 调用插件的 Close
+//
+// 回调中的 panic 会被捕获并记录（见 scheduler.PanicHandler），不影响主程序；Every 任务之后继续按周期执行
+type Scheduler interface {
+	// Every 每隔 interval 执行一次 fn（首次在 interval 之后）
+	// 上一次执行未结束时跳过本次，不会并发执行
+	Every(name string, interval time.Duration, fn func(ctx context.Context)) (Task, error)
+
+	// After 在 delay 之后执行一次 fn
+	After(name string, delay time.Duration, fn func(ctx context.Context)) (Task, error)
+
+	// Go 立即在后台执行 fn，fn 应在 ctx 取消后尽快返回
+	Go(name string, fn func(ctx context.Context)) (Task, error)
+}
+
+// Task 托管任务
+type Task interface {
+	// Name 任务名称（用于日志与泄漏报告）
+	Name() string
+
+	// Cancel 取消任务，正在执行的回调通过 ctx 感知
+	Cancel()
+
+	// Done 任务结束（已取消且回调已返回，或一次性任务执行完毕）后关闭
+	Done() <-chan struct{}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Threat Score & Ban Management
+
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultThreatThreshold 默认封禁阈值，同一对象累计威胁分达到后自动封禁
+const DefaultThreatThreshold = 100
+
+// SubjectKind 威胁对象类型
+type SubjectKind string
+
+const (
+	SubjectIP       SubjectKind = "ip"        // 按 IP
+	SubjectUsername SubjectKind = "username"  // 按用户名
+	SubjectClientID SubjectKind = "client_id" // 按客户端 ID
+)
+
+// Subject 威胁计分与封禁的对象
+type Subject struct {
+	Kind  SubjectKind
+	Value string
+}
+
+// IPSubject 构造 IP 对象
+func IPSubject(ip string) Subject { return Subject{Kind: SubjectIP, Value: ip} }
+
+// UsernameSubject 构造用户名对象
+func UsernameSubject(username string) Subject { return Subject{Kind: SubjectUsername, Value: username} }
+
+// ClientIDSubject 构造客户端 ID 对象
+func ClientIDSubject(clientID string) Subject { return Subject{Kind: SubjectClientID, Value: clientID} }
+
+// String 返回 "kind:value" 形式
+func (s Subject) String() string {
+	return string(s.Kind) + ":" + s.Value
+}
+
+// Validate 校验对象
+func (s Subject) Validate() error {
+	switch s.Kind {
+	case SubjectIP, SubjectUsername, SubjectClientID:
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidSubject, s.Kind)
+	}
+	if s.Value == "" {
+		return fmt.Errorf("%w: empty %s", ErrInvalidSubject, s.Kind)
+	}
+	return nil
+}
+
+// ParseSubject 解析 "kind:value" 形式的对象，如 "ip:10.0.0.1"
+func ParseSubject(str string) (Subject, error) {
+	kind, value, ok := strings.Cut(str, ":")
+	if !ok {
+		return Subject{}, fmt.Errorf("%w: %q", ErrInvalidSubject, str)
+	}
+	s := Subject{Kind: SubjectKind(kind), Value: value}
+	if err := s.Validate(); err != nil {
+		return Subject{}, err
+	}
+	return s, nil
+}
+
+// Ban 封禁记录
+type Ban struct {
+	Subject   Subject   // 封禁对象
+	Reason    string    // 封禁原因
+	CreatedAt time.Time // 封禁时间
+	ExpiresAt time.Time // 解封时间（零值表示永久）
+}
+
+// Permanent 是否为永久封禁
+func (b Ban) Permanent() bool {
+	return b.ExpiresAt.IsZero()
+}
+
+// SecurityManager 威胁计分与封禁管理
+// 与钩子中设置 ThreatScore 共用同一套计分，可在任意时间（如异步分析后）调用
+type SecurityManager interface {
+	// ReportThreat 为对象累加威胁分，返回累计后的分数
+	// score 必须为正数，否则返回 ErrInvalidThreatScore（分数只能通过 Unban 或封禁过期清零）
+	// 累计达到阈值时主程序自动封禁，并断开相关在线客户端
+	ReportThreat(subject Subject, score int, reason string) (total int, err error)
+
+	// ThreatScore 查询对象当前的累计威胁分
+	ThreatScore(subject Subject) int
+
+	// Ban 封禁对象，duration<=0 表示永久封禁
+	// 重复封禁将覆盖原记录
+	Ban(subject Subject, duration time.Duration, reason string) error
+
+	// Unban 解除封禁并清零威胁分，未封禁时返回 ErrBanNotFound
+	Unban(subject Subject) error
+
+	// Banned 查询对象是否处于封禁中
+	Banned(subject Subject) (ban Ban, ok bool)
+
+	// Bans 列出当前生效的封禁
+	Bans() []Ban
+}
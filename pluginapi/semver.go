@@ -0,0 +1,197 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Semantic Versioning
+
+package pluginapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version 语义化版本（主.次.修订[-预发布][+构建]）
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Pre   string // 预发布标识，如 "rc.1"；非空时版本低于对应的正式版
+	Build string // 构建元数据，不参与比较
+}
+
+// ParseVersion 解析语义化版本，允许 "v" 前缀，缺省的次版本与修订号为 0（如 "1"、"v1.2"）
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest, v.Build = rest[:i], rest[i+1:]
+		if v.Build == "" {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		rest, v.Pre = rest[:i], rest[i+1:]
+		if v.Pre == "" || strings.Contains(v.Pre, "..") || strings.HasPrefix(v.Pre, ".") || strings.HasSuffix(v.Pre, ".") {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if rest == "" || len(parts) > 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	nums := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// String 返回规范形式，如 "1.2.0-rc.1"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare 按语义化版本的优先级比较，返回 -1、0 或 1（忽略构建元数据）
+func (v Version) Compare(o Version) int {
+	for _, d := range [3]int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+// comparePre 逐段比较预发布标识：数字段按数值比较且低于字母段，段数多者更高
+func comparePre(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return sign(an - bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(as) - len(bs))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// VersionRange 版本范围：空格分隔的条件，全部满足才匹配，空字符串匹配任意版本
+//
+// 支持的条件：
+//   - ">=1.2.0"、">1.2"、"<=2"、"<2.0.0"、"=1.2.3"（或直接写 "1.2.3"）
+//   - "^1.2"：>=1.2.0 且 <2.0.0（主版本为 0 时为 <0.(次+1).0）
+//   - "~1.2.3"：>=1.2.3 且 <1.3.0
+type VersionRange struct {
+	raw   string
+	conds []versionCond
+}
+
+// versionCond 单个比较条件
+type versionCond struct {
+	op string // ">=", ">", "<=", "<", "="
+	v  Version
+}
+
+// ParseVersionRange 解析版本范围
+func ParseVersionRange(s string) (VersionRange, error) {
+	r := VersionRange{raw: s}
+	for _, field := range strings.Fields(s) {
+		op := ""
+		for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+			if strings.HasPrefix(field, prefix) {
+				op = prefix
+				break
+			}
+		}
+		v, err := ParseVersion(field[len(op):])
+		if err != nil {
+			return VersionRange{}, fmt.Errorf("%w: range %q", ErrInvalidVersion, s)
+		}
+		switch op {
+		case "^":
+			upper := Version{Major: v.Major + 1}
+			if v.Major == 0 {
+				upper = Version{Minor: v.Minor + 1}
+			}
+			r.conds = append(r.conds, versionCond{">=", v}, versionCond{"<", upper})
+		case "~":
+			r.conds = append(r.conds, versionCond{">=", v}, versionCond{"<", Version{Major: v.Major, Minor: v.Minor + 1}})
+		case "":
+			r.conds = append(r.conds, versionCond{"=", v})
+		default:
+			r.conds = append(r.conds, versionCond{op, v})
+		}
+	}
+	return r, nil
+}
+
+// Contains 判断版本是否在范围内
+func (r VersionRange) Contains(v Version) bool {
+	for _, c := range r.conds {
+		cmp := v.Compare(c.v)
+		ok := false
+		switch c.op {
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0
+		case "<":
+			ok = cmp < 0
+		case "=":
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// String 返回原始表达式，空范围返回 "*"
+func (r VersionRange) String() string {
+	if strings.TrimSpace(r.raw) == "" {
+		return "*"
+	}
+	return r.raw
+}
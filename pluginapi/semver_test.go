@@ -0,0 +1,151 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Semantic Versioning Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // 规范形式，空表示解析失败
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"1", "1.0.0"},
+		{"v1.2", "1.2.0"},
+		{"0.0.0", "0.0.0"},
+		{"1.2.3-rc.1", "1.2.3-rc.1"},
+		{"1.2.3+build.5", "1.2.3+build.5"},
+		{"1.2.3-beta+exp.sha", "1.2.3-beta+exp.sha"},
+		{"10.20.30", "10.20.30"},
+		{"", ""},
+		{"v", ""},
+		{"1.2.3.4", ""},
+		{"1.02.3", ""},
+		{"01.2.3", ""},
+		{"1.-2.3", ""},
+		{"1.2.x", ""},
+		{"1..3", ""},
+		{"1.2.3-", ""},
+		{"1.2.3+", ""},
+		{"1.2.3-.rc", ""},
+		{"1.2.3-rc.", ""},
+		{"1.2.3-rc..1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := ParseVersion(tt.in)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalidVersion) {
+					t.Fatalf("ParseVersion(%q) = %v, %v; want ErrInvalidVersion", tt.in, v, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVersion(%q): %v", tt.in, err)
+			}
+			if v.String() != tt.want {
+				t.Fatalf("ParseVersion(%q) = %s, want %s", tt.in, v, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.3.0", "1.2.9", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0+a", "1.0.0+b", 0},
+		// semver.org 第 11 条的优先级示例
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, b := mustVersion(t, tt.a), mustVersion(t, tt.b)
+			if got := a.Compare(b); got != tt.want {
+				t.Fatalf("Compare = %d, want %d", got, tt.want)
+			}
+			if got := b.Compare(a); got != -tt.want {
+				t.Fatalf("reverse Compare = %d, want %d", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	tests := []struct {
+		rng  string
+		in   []string
+		out  []string
+		text string
+	}{
+		{"", []string{"0.0.1", "1.0.0", "99.0.0-rc.1"}, nil, "*"},
+		{"1.2.3", []string{"1.2.3", "v1.2.3+build"}, []string{"1.2.4", "1.2.3-rc.1"}, "1.2.3"},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.2"}, "=1.2.3"},
+		{">=1.2", []string{"1.2.0", "3.0.0"}, []string{"1.1.9", "1.2.0-rc.1"}, ">=1.2"},
+		{">1.2.0", []string{"1.2.1"}, []string{"1.2.0"}, ">1.2.0"},
+		{"<2", []string{"1.99.0", "2.0.0-rc.1"}, []string{"2.0.0"}, "<2"},
+		{"<=2.0.0", []string{"2.0.0"}, []string{"2.0.1"}, "<=2.0.0"},
+		{">=2.0.0 <2.3.0", []string{"2.0.0", "2.2.9"}, []string{"1.9.9", "2.3.0"}, ">=2.0.0 <2.3.0"},
+		{"^1.4", []string{"1.4.0", "1.99.0"}, []string{"1.3.9", "2.0.0"}, "^1.4"},
+		{"^0.3", []string{"0.3.0", "0.3.7"}, []string{"0.2.9", "0.4.0"}, "^0.3"},
+		{"^0.0.3", []string{"0.0.3", "0.0.9"}, []string{"0.1.0"}, "^0.0.3"},
+		{"~1.4.2", []string{"1.4.2", "1.4.9"}, []string{"1.4.1", "1.5.0"}, "~1.4.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rng, func(t *testing.T) {
+			r, err := ParseVersionRange(tt.rng)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q): %v", tt.rng, err)
+			}
+			for _, s := range tt.in {
+				if !r.Contains(mustVersion(t, s)) {
+					t.Errorf("%q should contain %s", tt.rng, s)
+				}
+			}
+			for _, s := range tt.out {
+				if r.Contains(mustVersion(t, s)) {
+					t.Errorf("%q should not contain %s", tt.rng, s)
+				}
+			}
+			if r.String() != tt.text {
+				t.Errorf("String() = %q, want %q", r.String(), tt.text)
+			}
+		})
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	for _, s := range []string{">=", "^", "~x", ">=1.2 <abc", "1.2.3.4", ">>1"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseVersionRange(s); !errors.Is(err, ErrInvalidVersion) {
+				t.Fatalf("ParseVersionRange(%q) err = %v, want ErrInvalidVersion", s, err)
+			}
+		})
+	}
+}
+
+func mustVersion(t *testing.T, s string) Version {
+	t.Helper()
+	v, err := ParseVersion(s)
+	if err != nil {
+		t.Fatalf("ParseVersion(%q): %v", s, err)
+	}
+	return v
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Inter-plugin Service Registry
+
+package pluginapi
+
+import "fmt"
+
+// ServiceRegistry 插件间服务注册表（由主程序中转）
+//
+// 服务接口类型必须定义在提供方与消费方共同依赖的 Go 包中（同一模块、同一版本），
+// 否则类型断言会失败。典型用法：
+//
+//	// 提供方（user_directory 插件）
+//	host.Services().Register("user.directory", "1.2.0", dir)
+//
+//	// 消费方（acl 插件），每次使用时查找，不要长期缓存
+//	impl, err := host.Services().Lookup("user.directory", "1.1")
+//	dir := impl.(shared.UserDirectory)
+//
+// 加载顺序：主程序按 ResolveDependencies 的结果依次调用 InitWithHost（依赖先初始化）。
+// 需要在初始化阶段就使用服务的消费方，应在 Dependencies 中声明提供方；After 只影响钩子的执行顺序，不影响初始化顺序。
+//
+// 提供方卸载或热加载时：主程序在其排空（见 Drainer）之后注销其全部服务
+// （之后的 Lookup 返回 ErrServiceNotFound），再调用其 Close；新版本在 InitWithHost 中重新注册。服务变化通过 ServiceWatcher 通知。
+// 消费方已取得的旧实现在提供方 Close 后不应再调用。
+type ServiceRegistry interface {
+	// Register 注册服务，version 为 "主.次.修订" 形式
+	// 同一插件重复注册将替换原实现；名称已被其他插件占用时返回 ErrServiceAlreadyRegistered
+	Register(name, version string, impl any) error
+
+	// Unregister 注销本插件注册的服务
+	Unregister(name string) error
+
+	// Lookup 查找服务，要求主版本相同且不低于 minVersion（如 "1.2" 或 "1.2.0"，为空表示不限）
+	Lookup(name, minVersion string) (impl any, err error)
+}
+
+// ServiceEventType 服务变化类型
+type ServiceEventType int
+
+const (
+	ServiceRegistered   ServiceEventType = iota + 1 // 服务注册（含替换）
+	ServiceUnregistered                             // 服务注销（含提供方卸载）
+)
+
+// ServiceEvent 服务变化事件
+type ServiceEvent struct {
+	Type     ServiceEventType
+	Name     string // 服务名称
+	Version  string // 服务版本
+	Provider string // 提供方插件名称
+}
+
+// ServiceWatcher 可选接口：需要感知服务变化的插件实现此接口
+// 事件投递给除提供方以外的插件，不应在回调中长时间阻塞
+type ServiceWatcher interface {
+	OnServiceChange(event ServiceEvent)
+}
+
+// CheckServiceVersion 检查服务版本是否满足要求（主版本相同且不低于 minVersion）
+func CheckServiceVersion(version, minVersion string) error {
+	have, err := ParseVersion(version)
+	if err != nil {
+		return err
+	}
+	if minVersion == "" {
+		return nil
+	}
+	want, err := ParseVersion(minVersion)
+	if err != nil {
+		return err
+	}
+	if have.Major != want.Major {
+		return fmt.Errorf("%w: have %s, want %s", ErrServiceVersionMismatch, version, minVersion)
+	}
+	if have.Compare(want) < 0 {
+		return fmt.Errorf("%w: have %s, want >= %s", ErrServiceVersionMismatch, version, minVersion)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Graceful Shutdown
+
+package pluginapi
+
+import (
+	"context"
+	"time"
+)
+
+// 排空期限
+const (
+	DefaultDrainTimeout = 5 * time.Second  // 默认排空期限
+	MaxDrainTimeout     = 60 * time.Second // 最大允许排空期限
+)
+
+// Drainer 可选接口：缓冲事件的插件（如批量写入外部系统的 sink）在关闭前排空
+//
+// 主程序卸载、热升级或退出时按以下两个阶段关闭插件：
+//  1. 停止接收：不再向插件投递新事件，等待正在执行的钩子返回
+//  2. 排空并关闭：
+//     a. 调用 Drain，ctx 在 PluginMeta.DrainTimeout 后到期
+//     b. 注销插件提供的服务，取消托管任务（见 Scheduler）
+//     c. 调用 Close
+//
+// Drain 被调用时不会再有新事件到达，托管任务仍在运行，可用于写出数据。
+// ctx 到期后 Drain 应尽快返回（返回 ctx.Err() 即可）；主程序不会继续等待，
+// 记录未完成的排空后进入下一步，未写出的数据视为丢失。
+type Drainer interface {
+	Drain(ctx context.Context) error
+}
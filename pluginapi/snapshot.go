@@ -0,0 +1,35 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - State Handoff
+
+package pluginapi
+
+// Snapshot 插件热升级时交接的内存状态
+type Snapshot struct {
+	Plugin  string // 生成快照的插件名称（主程序填写）
+	Version string // 生成快照的插件版本（主程序填写）
+	Format  int    // 状态格式版本，由插件定义，格式变化时递增
+	Data    []byte // 序列化后的状态
+}
+
+// Snapshotter 可选接口：热升级时将内存状态（缓存、限流窗口、计数器等）交给新版本
+//
+// 覆盖 .so 时主程序的升级流程：
+//  1. 加载新版本并校验元信息（名称必须相同，执行顺序必须有效），失败则保留旧版本
+//  2. 暂停向该插件投递事件，期间的事件被暂存，不会丢失
+//  3. 调用旧实例的 Snapshot；返回 error 则放弃升级，旧版本恢复服务
+//  4. 关闭旧实例（Drain -> 注销服务 -> 取消托管任务 -> Close，见 Drainer）
+//  5. 初始化新实例（Init / InitWithHost），再调用其 Restore
+//  6. 恢复投递，暂存的事件交给新实例
+//
+// Restore 在任何钩子之前调用。遇到无法识别的 Format 时，插件可以返回 nil 以空状态启动，
+// 也可以返回 error（通常包装 ErrUnsupportedSnapshot）；后者只会被记录，新实例照常服务。
+// 新版本未实现 Snapshotter 时状态被丢弃。
+//
+// 步骤 2~6 期间事件被暂存，Snapshot 与 Restore 应尽快返回。
+type Snapshotter interface {
+	// Snapshot 序列化当前状态，只需填写 Format 与 Data
+	Snapshot() (Snapshot, error)
+
+	// Restore 从旧版本的快照恢复状态，应按 Format 迁移旧格式
+	Restore(snap Snapshot) error
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Plugin Key-Value Store
+
+package pluginapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// 存储限制
+const (
+	MaxKeySize   = 1024    // 键最大长度（字节）
+	MaxValueSize = 1 << 20 // 值最大长度（1MB）
+)
+
+// KVStore 插件专用的持久化键值存储
+// 每个插件拥有独立命名空间，数据在热加载与重启后保留；所有方法并发安全
+// 写入类方法返回错误时存储内容保持不变
+type KVStore interface {
+	// Get 读取键值，键不存在或已过期时 ok=false
+	Get(key string) (value []byte, ok bool, err error)
+
+	// Set 写入键值，ttl<=0 表示永不过期
+	Set(key string, value []byte, ttl time.Duration) error
+
+	// Delete 删除键，键不存在时不报错
+	Delete(key string) error
+
+	// Scan 按前缀列出未过期的键值（按键排序），prefix 为空时列出全部
+	Scan(prefix string) ([]KV, error)
+
+	// CompareAndSwap 当前值等于 old 时写入 new，返回是否写入
+	// old 为 nil 表示键必须不存在；new 为 nil 表示删除；写入失败时返回 false 与错误
+	CompareAndSwap(key string, old, new []byte, ttl time.Duration) (swapped bool, err error)
+}
+
+// KV 键值对
+type KV struct {
+	Key       string
+	Value     []byte
+	ExpiresAt time.Time // 过期时间（零值表示永不过期）
+}
+
+// ValidateKV 校验键与值的大小
+func ValidateKV(key string, value []byte) error {
+	if key == "" || len(key) > MaxKeySize {
+		return fmt.Errorf("%w: length %d (allowed 1~%d)", ErrInvalidKey, len(key), MaxKeySize)
+	}
+	if len(value) > MaxValueSize {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrValueTooLarge, len(value), MaxValueSize)
+	}
+	return nil
+}
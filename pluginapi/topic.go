@@ -0,0 +1,61 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Topic Matching
+
+package pluginapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateTopicFilter 校验订阅主题过滤器
+// '#' 只能作为最后一级单独出现，'+' 必须独占一级
+func ValidateTopicFilter(filter string) error {
+	if filter == "" {
+		return fmt.Errorf("%w: empty filter", ErrInvalidTopicFilter)
+	}
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		switch {
+		case level == "#":
+			if i != len(levels)-1 {
+				return fmt.Errorf("%w: %q ('#' must be the last level)", ErrInvalidTopicFilter, filter)
+			}
+		case level == "+":
+		case strings.ContainsAny(level, "+#"):
+			return fmt.Errorf("%w: %q (wildcard must occupy a whole level)", ErrInvalidTopicFilter, filter)
+		}
+	}
+	return nil
+}
+
+// MatchTopic 判断主题是否匹配过滤器（MQTT 规则）
+// 以 '$' 开头的主题（如 $SYS）不会被首级通配符匹配
+// filter 非法时返回 false
+func MatchTopic(filter, topic string) bool {
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return false
+	}
+	if strings.HasPrefix(topic, "$") && (strings.HasPrefix(filter, "+") || strings.HasPrefix(filter, "#")) {
+		return false
+	}
+
+	for {
+		fl, frest, fmore := strings.Cut(filter, "/")
+		tl, trest, tmore := strings.Cut(topic, "/")
+		switch fl {
+		case "#":
+			return !fmore
+		case "+":
+		default:
+			if fl != tl || strings.ContainsAny(fl, "+#") {
+				return false
+			}
+		}
+		if !fmore || !tmore {
+			// "a/#" 同时匹配 "a"
+			return fmore == tmore || (fmore && frest == "#")
+		}
+		filter, topic = frest, trest
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Topic Matching Tests
+
+package pluginapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTopicFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		ok     bool
+	}{
+		{"a/b/c", true},
+		{"#", true},
+		{"+", true},
+		{"a/#", true},
+		{"+/+/#", true},
+		{"/", true},
+		{"a//b", true},
+		{"$SYS/#", true},
+		{"", false},
+		{"#/a", false},
+		{"a/#/b", false},
+		{"a#", false},
+		{"a/b#", false},
+		{"a+", false},
+		{"a/+b/c", false},
+		{"++", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			err := ValidateTopicFilter(tt.filter)
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidTopicFilter) {
+				t.Fatalf("err = %v, want ErrInvalidTopicFilter", err)
+			}
+		})
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		filter, topic string
+		want          bool
+	}{
+		// 精确匹配
+		{"a/b", "a/b", true},
+		{"a/b", "a/c", false},
+		{"a/b", "a/b/c", false},
+		{"a/b/c", "a/b", false},
+		{"a/b", "A/b", false},
+
+		// 多级通配符
+		{"#", "a", true},
+		{"#", "a/b/c", true},
+		{"#", "/", true},
+		{"a/#", "a", true},
+		{"a/#", "a/", true},
+		{"a/#", "a/b/c", true},
+		{"a/#", "b/c", false},
+		{"a/b/#", "a", false},
+
+		// 单级通配符
+		{"+", "a", true},
+		{"+", "a/b", false},
+		{"+", "/a", false},
+		{"+/+", "/a", true},
+		{"a/+", "a/b", true},
+		{"a/+", "a/", true},
+		{"a/+", "a", false},
+		{"a/+", "a/b/c", false},
+		{"a/+/c", "a/b/c", true},
+		{"+/b/#", "a/b", true},
+		{"+/b/#", "a/b/c/d", true},
+
+		// 空级
+		{"a//b", "a//b", true},
+		{"a/+/b", "a//b", true},
+		{"/a", "/a", true},
+		{"/a", "a", false},
+
+		// $ 开头的主题
+		{"#", "$SYS/uptime", false},
+		{"+/uptime", "$SYS/uptime", false},
+		{"$SYS/#", "$SYS/uptime", true},
+		{"$SYS/+", "$SYS/uptime", true},
+		{"$SYS/#", "$SYS", true},
+		{"a/#", "a/$b", true},
+		{"a/+", "a/$b", true},
+
+		// 非法主题或过滤器
+		{"a/b", "", false},
+		{"#", "a/+", false},
+		{"#", "a/#", false},
+		{"a/b#", "a/b#", false},
+		{"a/+b", "a/+b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter+" "+tt.topic, func(t *testing.T) {
+			if got := MatchTopic(tt.filter, tt.topic); got != tt.want {
+				t.Fatalf("MatchTopic(%q, %q) = %v, want %v", tt.filter, tt.topic, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,69 @@
 
 package pluginapi
 
-// SDK 版本常量
-// 主程序和插件必须使用完全相同的 SDK 版本
+import (
+	"fmt"
+	"runtime"
+)
+
+// SDK 版本常量（语义化版本）
+//
+// 兼容策略（见 CheckSDKVersion）：
+//   - 主版本相同：主版本变化表示 Plugin 接口或已有类型发生不兼容的修改
+//   - 插件的次版本不高于主程序：次版本只新增可选接口（如 Drainer、BatchPublisher）、
+//     PluginMeta 字段与 HostAPI 方法，旧插件不实现新接口即可继续加载
+//   - 修订号不参与比较
+//   - 0.x 版本不稳定，次版本也必须相同
+//
+// 新功能一律以可选接口的形式提供，主程序通过类型断言探测，插件无需为未使用的功能升级 SDK。
+//
+// HostAPI 及其子接口（ClientManager、Metrics、KVStore 等）由主程序实现，次版本会为其新增方法：
+// 只调用这些接口的插件不受影响，但自行实现它们的代码（测试替身、包装器）在升级 SDK 后将无法编译。
+//
+// 已有语义同样不在次版本中改变：例如共享的池化 PublishContext 需要插件声明 PluginMeta.SharedPublish，
+// 未声明的插件（包括所有 1.0 插件）仍收到可以在返回后继续引用的独立副本。
+//
+// 版本历史：
+//   - 1.1.0：执行顺序、HostAPI（发布、客户端、威胁计分、日志、指标、存储、任务、服务、集群、Broker 状态）、
+//     配置热更新、状态交接、健康检查、优雅关闭、钩子声明与策略、预过滤、批量投递、共享发布上下文、插件依赖、配置 Schema、命名实例
+//   - 1.0.0：Plugin 接口与 BasePlugin
+//
+// 注意：Go 插件机制还要求主程序与插件链接的 pluginapi 包完全一致，
+// 否则 plugin.Open 失败（"plugin was built with a different version of package"）。
+// 兼容策略用于在打开 .so 之前（校验 .meta.json）给出明确的错误，并约束 SDK 的演进方式。
 const (
-	SDKVersion = "1.0.0"
+	SDKVersion = "1.1.0"
 )
+
+// CheckSDKVersion 检查插件构建时使用的 SDK 版本能否被当前主程序加载
+func CheckSDKVersion(pluginSDK string) error {
+	if pluginSDK == "" {
+		return ErrMissingSDKVersion
+	}
+	have, err := ParseVersion(SDKVersion)
+	if err != nil {
+		return err
+	}
+	want, err := ParseVersion(pluginSDK)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSDKVersionMismatch, err)
+	}
+	switch {
+	case want.Major != have.Major:
+		return fmt.Errorf("%w: plugin built with SDK %s, host provides %s (major version differs)", ErrSDKVersionMismatch, pluginSDK, SDKVersion)
+	case want.Minor > have.Minor:
+		return fmt.Errorf("%w: plugin built with SDK %s, host provides %s (upgrade the host)", ErrSDKVersionMismatch, pluginSDK, SDKVersion)
+	case have.Major == 0 && want.Minor != have.Minor:
+		return fmt.Errorf("%w: plugin built with SDK %s, host provides %s (0.x minor versions are incompatible)", ErrSDKVersionMismatch, pluginSDK, SDKVersion)
+	}
+	return nil
+}
+
+// CheckGoVersion 检查插件的 Go 版本（PluginMeta.GoVersion）是否与主程序一致，未填写时跳过
+// Go 插件要求主程序与插件使用完全相同的工具链构建
+func CheckGoVersion(pluginGo string) error {
+	if pluginGo == "" || pluginGo == runtime.Version() {
+		return nil
+	}
+	return fmt.Errorf("%w: plugin built with %s, host runs %s", ErrGoVersionMismatch, pluginGo, runtime.Version())
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Batched Publish
+//
+// 为实现 BatchPublisher 的插件缓冲 OnPublish 事件，按 BatchSize / BatchLinger 投递
+// 关闭与热升级前投递缓冲中剩余的消息，语义与主程序一致
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// batcher 单个插件的发布缓冲
+type batcher struct {
+	lp     *loadedPlugin
+	bp     pluginapi.BatchPublisher
+	size   int
+	linger time.Duration
+
+	deliverMu sync.Mutex    // 保证 OnPublishBatch 不并发且按顺序投递，先于 mu 获取
+	running   chan struct{} // 超时后仍在执行的 OnPublishBatch，返回时关闭（deliverMu 保护）
+
+	mu      sync.Mutex
+	pending []pluginapi.PublishContext
+	timer   *time.Timer // 第一条消息进入缓冲时启动
+	closed  bool
+
+	batches atomic.Uint64 // 已投递的批次数
+	quiet   atomic.Bool   // 压测模式下不逐批打印
+}
+
+// newBatcher 插件实现了 BatchPublisher 时返回缓冲，否则返回 nil
+func newBatcher(lp *loadedPlugin) *batcher {
+	bp, ok := lp.Impl.(pluginapi.BatchPublisher)
+	if !ok {
+		return nil
+	}
+	size := lp.Meta.GetBatchSize()
+	return &batcher{
+		lp:      lp,
+		bp:      bp,
+		size:    size,
+		linger:  lp.Meta.GetBatchLinger(),
+		pending: make([]pluginapi.PublishContext, 0, size),
+	}
+}
+
+// add 缓冲一条消息，达到批大小时立即投递
+func (b *batcher) add(ctx pluginapi.PublishContext) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = append(b.pending, ctx)
+	full := len(b.pending) >= b.size
+	if !full && len(b.pending) == 1 {
+		b.timer = time.AfterFunc(b.linger, func() { b.flush("linger") })
+	}
+	b.mu.Unlock()
+
+	if full {
+		b.flush("size")
+	}
+}
+
+// flush 投递缓冲中的消息，每次最多 BatchSize 条；reason 为 size 时只投递满批
+func (b *batcher) flush(reason string) {
+	b.deliverMu.Lock()
+	defer b.deliverMu.Unlock()
+	for {
+		b.mu.Lock()
+		n := min(len(b.pending), b.size)
+		if n == 0 || (reason == "size" && n < b.size) {
+			b.mu.Unlock()
+			return
+		}
+		batch := b.pending[:n:n]
+		b.pending = append(make([]pluginapi.PublishContext, 0, b.size), b.pending[n:]...)
+		if b.timer != nil {
+			b.timer.Stop()
+			b.timer = nil
+		}
+		if len(b.pending) > 0 {
+			b.timer = time.AfterFunc(b.linger, func() { b.flush("linger") })
+		}
+		b.mu.Unlock()
+
+		b.deliver(batch, reason)
+	}
+}
+
+// deliver 调用 OnPublishBatch，超时或 panic 时整批跳过（调用方持有 deliverMu）
+// 超时的调用仍在执行时跳过后续批次，直到它返回，保证同一插件的 OnPublishBatch 不并发
+func (b *batcher) deliver(batch []pluginapi.PublishContext, reason string) {
+	name := b.lp.Name()
+	if b.running != nil {
+		select {
+		case <-b.running:
+			b.running = nil
+		default:
+			fmt.Printf("  %s: previous OnPublishBatch still running, %d message(s) skipped\n", name, len(batch))
+			return
+		}
+	}
+
+	b.lp.delivered.Add(uint64(len(batch)))
+	b.batches.Add(1)
+	timeout := b.lp.Meta.GetHookPolicy(pluginapi.HookPublish).Timeout
+	running := make(chan struct{})
+	_, err := callHook(timeout, func() struct{} {
+		defer close(running)
+		b.bp.OnPublishBatch(batch)
+		return struct{}{}
+	})
+	if err != nil {
+		if errors.Is(err, errHookTimedOut) {
+			b.running = running
+		}
+		fmt.Printf("  %s: OnPublishBatch(%d) %v, skipped\n", name, len(batch), err)
+		return
+	}
+	if !b.quiet.Load() {
+		fmt.Printf("[host] %s: OnPublishBatch delivered %d message(s) (%s)\n", name, len(batch), reason)
+	}
+}
+
+// close 停止接收并投递剩余消息（关闭的第一阶段结束时调用）
+// 停止接收与取出缓冲在同一临界区内完成，之后 add 的消息被丢弃，不会滞留在缓冲中
+func (b *batcher) close(reason string) {
+	b.deliverMu.Lock()
+	defer b.deliverMu.Unlock()
+
+	b.mu.Lock()
+	b.closed = true
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := min(len(pending), b.size)
+		b.deliver(pending[:n:n], reason)
+		pending = pending[n:]
+	}
+}
+
+// flushBatches 立即投递所有插件缓冲中的消息
+func (d *dispatcher) flushBatches(reason string) {
+	for _, lp := range d.current() {
+		if lp.batch != nil {
+			lp.batch.flush(reason)
+		}
+	}
+}
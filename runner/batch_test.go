@@ -0,0 +1,74 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Batched Publish Tests
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// batchPlugin 统计收到的消息数
+type batchPlugin struct {
+	pluginapi.BasePlugin
+	received atomic.Uint64
+}
+
+func (p *batchPlugin) Info() pluginapi.PluginMeta {
+	return pluginapi.PluginMeta{Name: "batch", Version: "1.0.0", SDKVersion: pluginapi.SDKVersion,
+		Hooks: pluginapi.HookPublish, BatchSize: 4, BatchLinger: time.Millisecond}
+}
+
+func (p *batchPlugin) Init(config []byte) error { return nil }
+
+func (p *batchPlugin) OnPublishBatch(batch []pluginapi.PublishContext) {
+	p.received.Add(uint64(len(batch)))
+}
+
+// TestBatcherCloseDuringAdd close 与 add 并发时，close 返回后缓冲为空，已接收的消息都已投递
+func TestBatcherCloseDuringAdd(t *testing.T) {
+	for round := 0; round < 50; round++ {
+		impl := &batchPlugin{}
+		lp := &loadedPlugin{Plugin: &loader.Plugin{Spec: loader.Spec{Path: "batch.so"}, Impl: impl, Meta: impl.Info()}}
+		b := newBatcher(lp)
+		b.quiet.Store(true)
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					b.add(pluginapi.PublishContext{Topic: fmt.Sprintf("t/%d", i)})
+				}
+			}()
+		}
+		time.Sleep(time.Duration(round%5) * 100 * time.Microsecond)
+		b.close("shutdown")
+
+		b.mu.Lock()
+		left := len(b.pending)
+		b.mu.Unlock()
+		if left != 0 {
+			t.Fatalf("round %d: %d message(s) left in the buffer after close", round, left)
+		}
+		wg.Wait()
+		b.flush("linger") // 关闭后的 add 被丢弃，不会产生新的批次
+
+		b.mu.Lock()
+		left = len(b.pending)
+		b.mu.Unlock()
+		if left != 0 {
+			t.Fatalf("round %d: %d message(s) buffered after close", round, left)
+		}
+		if got, want := impl.received.Load(), lp.delivered.Load(); got != want {
+			t.Fatalf("round %d: plugin received %d message(s), host counted %d", round, got, want)
+		}
+	}
+}
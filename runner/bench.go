@@ -0,0 +1,188 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Benchmark
+//
+// 压测 OnPublish 分发，评估插件在高消息速率下的开销
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// benchResult 一轮压测的结果
+type benchResult struct {
+	elapsed time.Duration
+	allocs  float64 // 每条消息的分配次数
+	bytes   float64 // 每条消息的分配字节数
+}
+
+// runBench 连续触发 n 次 OnPublish（所有插件共享池化的上下文，与主程序一致）
+// upgradePath 非空时在压测进行到一半时热升级，并核对每个事件都恰好投递了一次
+func runBench(d *dispatcher, n int, topic string, payloadSize int, upgradePath string) {
+	fmt.Printf("Benchmark: %d x OnPublish, topic=%s, payload=%d bytes\n", n, topic, payloadSize)
+
+	base := make(map[*loadedPlugin]uint64)
+	for _, lp := range d.current() {
+		base[lp] = lp.delivered.Load()
+	}
+	upgraded := make(chan error, 1)
+
+	r := measure(d, n, topic, payloadSize, func(i int) {
+		if upgradePath != "" && i == n/2 {
+			// 与主程序一致，升级在后台进行，期间的事件等待升级完成
+			go func() { upgraded <- d.upgrade(upgradePath) }()
+		}
+	})
+
+	fmt.Printf("  Total:     %v\n", r.elapsed)
+	fmt.Printf("  Per op:    %v\n", r.elapsed/time.Duration(n))
+	fmt.Printf("  Rate:      %.0f msg/s\n", float64(n)/r.elapsed.Seconds())
+	fmt.Printf("  Allocs/op: %.1f\n", r.allocs)
+	fmt.Printf("  Bytes/op:  %.1f\n", r.bytes)
+	for _, lp := range d.current() {
+		if lp.batch == nil {
+			continue
+		}
+		if batches := lp.batch.batches.Load(); batches > 0 {
+			fmt.Printf("  Batches:   %s %d, avg %.1f msg/batch (size %d, linger %v)\n",
+				lp.Name(), batches, float64(lp.delivered.Load())/float64(batches), lp.batch.size, lp.batch.linger)
+		}
+	}
+
+	if upgradePath == "" {
+		return
+	}
+	if err := <-upgraded; err != nil {
+		fmt.Printf("  Upgrade:   FAILED: %v\n", err)
+	}
+	checkDelivered(d, base, topic, uint64(n))
+}
+
+// compareBench 分别以逐插件复制上下文（池化之前）与共享池化上下文的方式分发 n 条消息，对比分发开销
+func compareBench(d *dispatcher, n int, topic string, payloadSize int) {
+	timeouts := "on"
+	if d.syncHooks {
+		timeouts = "off (synchronous)"
+	}
+	fmt.Printf("Benchmark: %d x OnPublish, topic=%s, payload=%d bytes, %d plugin(s), hook timeouts %s\n",
+		n, topic, payloadSize, len(d.current()), timeouts)
+	shared := 0
+	for _, lp := range d.current() {
+		if lp.Meta.SharedPublish {
+			shared++
+		}
+	}
+	if shared == 0 {
+		fmt.Println("  Note: no plugin sets SharedPublish, pooled mode still copies the context for every plugin")
+	}
+	fmt.Printf("  %-8s %12s %12s %12s\n", "Mode", "ns/op", "allocs/op", "B/op")
+
+	var results [2]benchResult
+	for i, copyContexts := range []bool{true, false} {
+		d.copyContexts = copyContexts
+		measure(d, min(n, 10000), topic, payloadSize, nil) // 预热对象池
+		results[i] = measure(d, n, topic, payloadSize, nil)
+		mode := "pooled"
+		if copyContexts {
+			mode = "copy"
+		}
+		r := results[i]
+		fmt.Printf("  %-8s %12d %12.1f %12.1f\n", mode, (r.elapsed / time.Duration(n)).Nanoseconds(), r.allocs, r.bytes)
+	}
+	d.copyContexts = false
+
+	copied, pooled := results[0], results[1]
+	fmt.Printf("  Saved:   %.1f%% time, %.1f allocs/op, %.1f B/op\n",
+		100*(1-pooled.elapsed.Seconds()/copied.elapsed.Seconds()), copied.allocs-pooled.allocs, copied.bytes-pooled.bytes)
+}
+
+// measure 分发 n 条消息并统计耗时与内存分配，before 在每条消息之前调用（可为 nil）
+func measure(d *dispatcher, n int, topic string, payloadSize int, before func(i int)) benchResult {
+	payload := make([]byte, payloadSize)
+	for i := range payload {
+		payload[i] = byte('a' + i%26)
+	}
+	msg := pluginapi.PublishContext{
+		ClientID: "bench",
+		Username: "bench",
+		Topic:    topic,
+		Payload:  payload,
+		QoS:      0,
+	}
+
+	var memBefore, memAfter runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&memBefore)
+	start := time.Now()
+
+	for i := 0; i < n; i++ {
+		if before != nil {
+			before(i)
+		}
+		d.dispatchPublish(&msg)
+	}
+	d.flushBatches("bench") // 不足一批的剩余消息计入耗时
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&memAfter)
+
+	// Broker 状态不属于分发开销，统计结束后再计入
+	routed := pluginapi.Message{Topic: msg.Topic, Payload: msg.Payload, QoS: msg.QoS}
+	for i := 0; i < n; i++ {
+		d.host.broker.routed(routed)
+	}
+	return benchResult{
+		elapsed: elapsed,
+		allocs:  float64(memAfter.Mallocs-memBefore.Mallocs) / float64(n),
+		bytes:   float64(memAfter.TotalAlloc-memBefore.TotalAlloc) / float64(n),
+	}
+}
+
+// checkDelivered 按实例的完整名称汇总升级前后收到的事件数，核对热升级期间没有丢失或重复
+// 主题不匹配 PublishFilter 的插件应收到 0 条；采样的插件只打印数量
+// 预过滤条件取自升级后的实例；升级失败被下线的实例不在 d.current() 中，取升级前的实例
+func checkDelivered(d *dispatcher, base map[*loadedPlugin]uint64, topic string, n uint64) {
+	current := make(map[*loadedPlugin]bool)
+	for _, lp := range d.current() {
+		current[lp] = true
+		if _, ok := base[lp]; !ok {
+			base[lp] = 0
+		}
+	}
+	total := make(map[string]uint64)
+	filters := make(map[string]*pluginapi.PublishFilter)
+	var names []string
+	for lp, start := range base {
+		if !lp.Meta.GetHooks().Has(pluginapi.HookPublish) {
+			continue
+		}
+		if _, ok := total[lp.Name()]; !ok {
+			names = append(names, lp.Name())
+			filters[lp.Name()] = lp.Meta.PublishFilter
+		} else if current[lp] {
+			filters[lp.Name()] = lp.Meta.PublishFilter
+		}
+		total[lp.Name()] += lp.delivered.Load() - start
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := filters[name]
+		want := n
+		if !f.Match(topic, 0) {
+			want = 0
+		} else if f != nil && f.SampleRate > 0 && f.SampleRate < 1 {
+			fmt.Printf("  Delivered: %s %d/%d sampled (rate %v)\n", name, total[name], n, f.SampleRate)
+			continue
+		}
+		status := "OK"
+		if total[name] != want {
+			status = "MISMATCH"
+		}
+		fmt.Printf("  Delivered: %s %d/%d %s\n", name, total[name], want, status)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Dispatch Benchmarks
+//
+// 对比逐插件复制上下文（池化之前）与共享池化上下文的 OnPublish 分发开销：
+//   go test ./runner -run '^$' -bench Publish -benchmem
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// nopPlugin 只读取上下文的插件，分发开销之外不产生分配
+type nopPlugin struct {
+	pluginapi.BasePlugin
+	name  string
+	bytes int
+}
+
+func (p *nopPlugin) Info() pluginapi.PluginMeta {
+	return pluginapi.PluginMeta{Name: p.name, Version: "1.0.0", SDKVersion: pluginapi.SDKVersion, Hooks: pluginapi.HookPublish, SharedPublish: true}
+}
+
+func (p *nopPlugin) Init(config []byte) error { return nil }
+
+func (p *nopPlugin) OnPublish(ctx *pluginapi.PublishContext) {
+	p.bytes += len(ctx.Payload)
+}
+
+// benchDispatcher 创建加载了 n 个 nopPlugin 的分发器
+func benchDispatcher(n int) *dispatcher {
+	plugins := make([]*loadedPlugin, n)
+	for i := range plugins {
+		impl := &nopPlugin{name: fmt.Sprintf("nop%d", i)}
+		plugins[i] = &loadedPlugin{Plugin: &loader.Plugin{Impl: impl, Meta: impl.Info()}}
+	}
+	return newDispatcher(plugins, newRecordingHost(nil, nil), "")
+}
+
+func BenchmarkPublish(b *testing.B) {
+	msg := pluginapi.PublishContext{ClientID: "bench", Username: "bench", Topic: "bench/test", Payload: make([]byte, 64)}
+	modes := []struct {
+		name         string
+		copyContexts bool
+		syncHooks    bool
+	}{
+		{"copy", true, true},
+		{"pooled", false, true},
+		{"copy-timeouts", true, false},
+		{"pooled-timeouts", false, false},
+	}
+	for _, plugins := range []int{1, 4} {
+		for _, mode := range modes {
+			b.Run(fmt.Sprintf("%s/plugins=%d", mode.name, plugins), func(b *testing.B) {
+				d := benchDispatcher(plugins)
+				d.copyContexts = mode.copyContexts
+				d.syncHooks = mode.syncHooks
+				d.setBenchmark(true) // 与 -bench 一致，不核对共享上下文是否被修改
+				b.ReportAllocs()
+				b.SetBytes(int64(len(msg.Payload)))
+				for i := 0; i < b.N; i++ {
+					d.dispatchPublish(&msg)
+				}
+			})
+		}
+	}
+}
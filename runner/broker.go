@@ -0,0 +1,185 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Broker State
+//
+// 根据分发过的 PUBLISH 模拟保留消息、主题树与 $SYS 统计
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// rateWindow 速率统计窗口
+const rateWindow = time.Minute
+
+// memBroker 内存版 BrokerState 实现
+type memBroker struct {
+	clients *memClients
+	started time.Time
+
+	mu        sync.Mutex
+	retained  map[string]pluginapi.Message
+	topics    map[string]struct{}
+	received  uint64
+	sent      uint64
+	bytesIn   uint64
+	bytesOut  uint64
+	recvTimes []time.Time // 窗口内收到消息的时间
+	sentTimes []time.Time // 窗口内发出消息的时间
+}
+
+var _ pluginapi.BrokerState = (*memBroker)(nil)
+
+func newMemBroker(clients *memClients) *memBroker {
+	return &memBroker{
+		clients:  clients,
+		started:  time.Now(),
+		retained: make(map[string]pluginapi.Message),
+		topics:   make(map[string]struct{}),
+	}
+}
+
+// routed 记录一条被 Broker 接收并分发的消息
+// 保留消息的载荷为空时清除该主题的保留消息，与 MQTT 规则一致
+func (b *memBroker) routed(msg pluginapi.Message) {
+	subscribers := b.clients.countMatching(msg.Topic)
+	size := uint64(len(msg.Payload))
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topics[msg.Topic] = struct{}{}
+	if msg.Retain {
+		if len(msg.Payload) == 0 {
+			delete(b.retained, msg.Topic)
+		} else {
+			msg.Payload = append([]byte(nil), msg.Payload...)
+			b.retained[msg.Topic] = msg
+		}
+	}
+
+	b.received++
+	b.bytesIn += size
+	b.recvTimes = append(trimWindow(b.recvTimes, now), now)
+	b.sent += uint64(subscribers)
+	b.bytesOut += size * uint64(subscribers)
+	for i := 0; i < subscribers; i++ {
+		b.sentTimes = append(b.sentTimes, now)
+	}
+	b.sentTimes = trimWindow(b.sentTimes, now)
+}
+
+// trimWindow 丢弃窗口之外的时间点
+func trimWindow(times []time.Time, now time.Time) []time.Time {
+	cut := sort.Search(len(times), func(i int) bool { return now.Sub(times[i]) < rateWindow })
+	return times[cut:]
+}
+
+// Retained 返回主题上的保留消息
+func (b *memBroker) Retained(topic string) (*pluginapi.Message, bool, error) {
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return nil, false, fmt.Errorf("%w: %q", pluginapi.ErrInvalidTopic, topic)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	msg, ok := b.retained[topic]
+	if !ok {
+		return nil, false, nil
+	}
+	msg.Payload = append([]byte(nil), msg.Payload...)
+	return &msg, true, nil
+}
+
+// Topics 返回匹配 filter 的已知主题
+func (b *memBroker) Topics(filter string, limit int) ([]string, error) {
+	if err := pluginapi.ValidateTopicFilter(filter); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	var topics []string
+	for topic := range b.topics {
+		if pluginapi.MatchTopic(filter, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	b.mu.Unlock()
+
+	sort.Strings(topics)
+	if limit > 0 && len(topics) > limit {
+		topics = topics[:limit]
+	}
+	return topics, nil
+}
+
+// SubscriberCount 返回匹配主题的订阅数
+func (b *memBroker) SubscriberCount(topic string) (int, error) {
+	if topic == "" || strings.ContainsAny(topic, "+#") {
+		return 0, fmt.Errorf("%w: %q", pluginapi.ErrInvalidTopic, topic)
+	}
+	return b.clients.countMatching(topic), nil
+}
+
+// Stats 返回统计
+func (b *memBroker) Stats() pluginapi.BrokerStats {
+	connected, total, subs := b.clients.counts()
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.recvTimes = trimWindow(b.recvTimes, now)
+	b.sentTimes = trimWindow(b.sentTimes, now)
+	return pluginapi.BrokerStats{
+		Uptime:           now.Sub(b.started),
+		ClientsConnected: connected,
+		ClientsTotal:     total,
+		Subscriptions:    subs,
+		RetainedMessages: len(b.retained),
+		MessagesReceived: b.received,
+		MessagesSent:     b.sent,
+		BytesReceived:    b.bytesIn,
+		BytesSent:        b.bytesOut,
+		ReceivedRate:     float64(len(b.recvTimes)) / rateWindow.Seconds(),
+		SentRate:         float64(len(b.sentTimes)) / rateWindow.Seconds(),
+	}
+}
+
+func handleBroker(host *recordingHost, args []string) {
+	if len(args) > 0 {
+		topics, err := host.broker.Topics(args[0], 0)
+		if err != nil {
+			fmt.Printf("Invalid filter: %v\n", err)
+			return
+		}
+		if len(topics) == 0 {
+			fmt.Println("No matching topics")
+		}
+		for _, topic := range topics {
+			n, _ := host.broker.SubscriberCount(topic)
+			line := fmt.Sprintf("%s: subscribers=%d", topic, n)
+			if msg, ok, _ := host.broker.Retained(topic); ok {
+				line += fmt.Sprintf(", retained=%q (qos=%d)", msg.Payload, msg.QoS)
+			}
+			fmt.Println(line)
+		}
+		return
+	}
+
+	s := host.broker.Stats()
+	fmt.Printf("$SYS/broker/uptime: %s\n", s.Uptime.Truncate(time.Second))
+	fmt.Printf("$SYS/broker/clients/connected: %d\n", s.ClientsConnected)
+	fmt.Printf("$SYS/broker/clients/total: %d\n", s.ClientsTotal)
+	fmt.Printf("$SYS/broker/subscriptions/count: %d\n", s.Subscriptions)
+	fmt.Printf("$SYS/broker/retained messages/count: %d\n", s.RetainedMessages)
+	fmt.Printf("$SYS/broker/messages/received: %d\n", s.MessagesReceived)
+	fmt.Printf("$SYS/broker/messages/sent: %d\n", s.MessagesSent)
+	fmt.Printf("$SYS/broker/bytes/received: %d\n", s.BytesReceived)
+	fmt.Printf("$SYS/broker/bytes/sent: %d\n", s.BytesSent)
+	fmt.Printf("$SYS/broker/load/messages/received/1min: %.2f\n", s.ReceivedRate)
+	fmt.Printf("$SYS/broker/load/messages/sent/1min: %.2f\n", s.SentRate)
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Client Registry
+//
+// 内存版 ClientManager，根据执行过的钩子跟踪客户端与订阅
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+type memClient struct {
+	info pluginapi.ClientInfo
+	subs map[string]uint8 // topic -> qos
+}
+
+// memClients 内存版 ClientManager 实现
+type memClients struct {
+	mu      sync.Mutex
+	clients map[string]*memClient
+	kicked  []pluginapi.DisconnectContext // 等待触发 OnDisconnect 的客户端
+}
+
+var _ pluginapi.ClientManager = (*memClients)(nil)
+
+func newMemClients() *memClients {
+	return &memClients{clients: make(map[string]*memClient)}
+}
+
+// connected 认证通过后记录客户端上线
+func (c *memClients) connected(clientID, username, ip string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok {
+		cl = &memClient{subs: make(map[string]uint8)}
+		c.clients[clientID] = cl
+	}
+	cl.info = pluginapi.ClientInfo{
+		ClientID:    clientID,
+		Username:    username,
+		IP:          ip,
+		Online:      true,
+		ConnectedAt: time.Now(),
+	}
+}
+
+// subscribed 订阅通过后记录订阅
+func (c *memClients) subscribed(clientID, topic string, qos uint8) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok := c.clients[clientID]; ok {
+		cl.subs[topic] = qos
+	}
+}
+
+// disconnected 客户端断开后标记离线（保留会话与订阅）
+func (c *memClients) disconnected(clientID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok := c.clients[clientID]; ok {
+		cl.info.Online = false
+	}
+}
+
+// ipOf 返回已知客户端的 IP
+func (c *memClients) ipOf(clientID string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok := c.clients[clientID]; ok {
+		return cl.info.IP
+	}
+	return ""
+}
+
+// matching 返回匹配封禁对象的在线客户端
+func (c *memClients) matching(subject pluginapi.Subject) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ids []string
+	for id, cl := range c.clients {
+		if !cl.info.Online {
+			continue
+		}
+		switch subject.Kind {
+		case pluginapi.SubjectIP:
+			if cl.info.IP != subject.Value {
+				continue
+			}
+		case pluginapi.SubjectUsername:
+			if cl.info.Username != subject.Value {
+				continue
+			}
+		case pluginapi.SubjectClientID:
+			if id != subject.Value {
+				continue
+			}
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// takeKicked 取出等待触发 OnDisconnect 的客户端
+func (c *memClients) takeKicked() []pluginapi.DisconnectContext {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kicked := c.kicked
+	c.kicked = nil
+	return kicked
+}
+
+// Disconnect 踢下线，OnDisconnect 在当前钩子返回后触发
+func (c *memClients) Disconnect(clientID string, reason pluginapi.ReasonCode) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok || !cl.info.Online {
+		return fmt.Errorf("%w: %s", pluginapi.ErrClientNotFound, clientID)
+	}
+	cl.info.Online = false
+	c.kicked = append(c.kicked, pluginapi.DisconnectContext{
+		ClientID: clientID,
+		Username: cl.info.Username,
+		Reason:   pluginapi.DisconnectReasonKicked,
+	})
+	fmt.Printf("[host] disconnect: client=%s, reason=0x%02X\n", clientID, uint8(reason))
+	return nil
+}
+
+// Lookup 查询客户端信息
+func (c *memClients) Lookup(clientID string) (pluginapi.ClientInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok {
+		return pluginapi.ClientInfo{}, false
+	}
+	return cl.info, true
+}
+
+// Subscriptions 列出订阅（按主题排序）
+func (c *memClients) Subscriptions(clientID string) ([]pluginapi.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", pluginapi.ErrClientNotFound, clientID)
+	}
+	subs := make([]pluginapi.Subscription, 0, len(cl.subs))
+	for topic, qos := range cl.subs {
+		subs = append(subs, pluginapi.Subscription{Topic: topic, QoS: qos})
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Topic < subs[j].Topic })
+	return subs, nil
+}
+
+// Subscribe 代替客户端订阅
+func (c *memClients) Subscribe(clientID string, subs ...pluginapi.Subscription) error {
+	for _, s := range subs {
+		if err := pluginapi.ValidateTopicFilter(s.Topic); err != nil {
+			return err
+		}
+		if s.QoS > 2 {
+			return fmt.Errorf("%w: %d", pluginapi.ErrInvalidQoS, s.QoS)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok {
+		return fmt.Errorf("%w: %s", pluginapi.ErrClientNotFound, clientID)
+	}
+	for _, s := range subs {
+		cl.subs[s.Topic] = s.QoS
+		fmt.Printf("[host] subscribe: client=%s, topic=%s, qos=%d\n", clientID, s.Topic, s.QoS)
+	}
+	return nil
+}
+
+// Unsubscribe 代替客户端取消订阅
+func (c *memClients) Unsubscribe(clientID string, topics ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl, ok := c.clients[clientID]
+	if !ok {
+		return fmt.Errorf("%w: %s", pluginapi.ErrClientNotFound, clientID)
+	}
+	for _, topic := range topics {
+		if _, ok := cl.subs[topic]; ok {
+			delete(cl.subs, topic)
+			fmt.Printf("[host] unsubscribe: client=%s, topic=%s\n", clientID, topic)
+		}
+	}
+	return nil
+}
+
+// list 返回所有已知客户端（按 ClientID 排序）
+func (c *memClients) list() []pluginapi.ClientInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	infos := make([]pluginapi.ClientInfo, 0, len(c.clients))
+	for _, cl := range c.clients {
+		infos = append(infos, cl.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ClientID < infos[j].ClientID })
+	return infos
+}
+
+// countMatching 返回订阅过滤器匹配主题的订阅数
+func (c *memClients) countMatching(topic string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, cl := range c.clients {
+		for filter := range cl.subs {
+			if pluginapi.MatchTopic(filter, topic) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// counts 返回在线客户端数、客户端总数与订阅总数
+func (c *memClients) counts() (connected, total, subs int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cl := range c.clients {
+		if cl.info.Online {
+			connected++
+		}
+		subs += len(cl.subs)
+	}
+	return connected, len(c.clients), subs
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Cluster
+//
+// 模拟多节点集群：插件运行在本地节点上，其余节点只参与成员与 leader 变化
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/cluster"
+)
+
+// newCluster 创建 node-1 ... node-N 组成的集群视图，self 为插件所在节点
+func newCluster(nodes int, self, leader string) (*cluster.Membership, error) {
+	if nodes < 1 {
+		return nil, fmt.Errorf("invalid node count: %d", nodes)
+	}
+	ids := make([]string, nodes)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("node-%d", i+1)
+	}
+	if self == "" {
+		self = ids[0]
+	}
+	if !slices.Contains(ids, self) {
+		return nil, fmt.Errorf("node %s is not one of node-1 ... node-%d", self, nodes)
+	}
+	if leader == "" {
+		leader = ids[0]
+	}
+
+	m := cluster.New(self, nodeAddr(self))
+	for _, id := range ids {
+		if id == self {
+			continue
+		}
+		if err := m.Join(id, nodeAddr(id)); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.SetLeader(leader); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// nodeAddr 模拟的集群通信地址
+func nodeAddr(id string) string {
+	return id + ":7946"
+}
+
+// clusterAction 执行拓扑变化：join/leave/leader
+func clusterAction(m *cluster.Membership, action, node string) error {
+	switch action {
+	case "join":
+		return m.Join(node, nodeAddr(node))
+	case "leave":
+		return m.Leave(node)
+	case "leader":
+		return m.SetLeader(node)
+	default:
+		return fmt.Errorf("unknown cluster action: %s", action)
+	}
+}
+
+// printCluster 显示集群成员
+func printCluster(m *cluster.Membership) {
+	leader := m.Leader()
+	if leader == "" {
+		leader = "(election in progress)"
+	}
+	fmt.Printf("Node: %s, leader: %s\n", m.NodeID(), leader)
+	for _, mb := range m.Members() {
+		mark := ""
+		if mb.Leader {
+			mark += " [leader]"
+		}
+		if mb.Self {
+			mark += " [self]"
+		}
+		fmt.Printf("  %s %s%s\n", mb.ID, mb.Address, mark)
+	}
+}
+
+func handleCluster(host *recordingHost, args []string) {
+	if len(args) == 0 {
+		printCluster(host.cluster)
+		return
+	}
+	if len(args) < 2 && args[0] != "leader" {
+		fmt.Println("Usage: cluster [join <node> | leave <node> | leader [node]]")
+		return
+	}
+	node := ""
+	if len(args) > 1 {
+		node = args[1]
+	}
+	if err := clusterAction(host.cluster, args[0], node); err != nil {
+		fmt.Printf("Cluster change failed: %v\n", err)
+		return
+	}
+	printCluster(host.cluster)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Config Tests
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/kvstore"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// configPlugin 像示例插件一样用 json.Unmarshal 解析配置
+type configPlugin struct {
+	pluginapi.BasePlugin
+	users map[string]string
+}
+
+func (p *configPlugin) Info() pluginapi.PluginMeta {
+	return pluginapi.PluginMeta{
+		Name: "cfg", Version: "1.0.0", SDKVersion: pluginapi.SDKVersion,
+		ConfigSchema: json.RawMessage(`{"type":"object","properties":{"users":{"type":"object","additionalProperties":{"type":"string"}},"log_level":{"type":"string"}},"additionalProperties":false}`),
+	}
+}
+
+func (p *configPlugin) Init(config []byte) error { return p.Reconfigure(config) }
+
+func (p *configPlugin) Reconfigure(config []byte) error {
+	var cfg struct {
+		Users map[string]string `json:"users"`
+	}
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return err
+		}
+	}
+	p.users = cfg.Users
+	return nil
+}
+
+// testHost 创建单节点、存储位于临时目录的模拟主程序
+func testHost(t *testing.T) *recordingHost {
+	t.Helper()
+	store, err := kvstore.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	membership, err := newCluster(1, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newRecordingHost(store, membership)
+}
+
+// TestYAMLConfig 按 -config 的流程读取 .yml 配置文件，插件的 Init / Reconfigure 收到 JSON
+func TestYAMLConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yml")
+	write := func(content string) []byte {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	impl := &configPlugin{}
+	lp := &loadedPlugin{Plugin: &loader.Plugin{Spec: loader.Spec{Path: "cfg.so", ConfigPath: path}, Impl: impl, Meta: impl.Info()}}
+	host := testHost(t)
+	if err := lp.init(host, write("# users\nusers:\n  admin: secret\nlog_level: debug\n"), ""); err != nil {
+		t.Fatalf("init with YAML config: %v", err)
+	}
+	defer lp.shutdown(host)
+	if impl.users["admin"] != "secret" {
+		t.Fatalf("users = %v, want admin=secret", impl.users)
+	}
+	if lp.host.level.Level().String() != "DEBUG" {
+		t.Fatalf("log level = %v, want DEBUG", lp.host.level.Level())
+	}
+
+	if err := lp.reconfigure(write("users:\n  ops: pass\n")); err != nil {
+		t.Fatalf("reconfigure with YAML config: %v", err)
+	}
+	if impl.users["ops"] != "pass" || len(impl.users) != 1 {
+		t.Fatalf("users = %v, want ops=pass", impl.users)
+	}
+
+	// 不符合 Schema 或无法解析的 YAML 被拒绝，旧配置继续生效
+	for _, bad := range []string{"users:\n  ops: 1\n", "users: [\n", "extra: true\n"} {
+		if err := lp.reconfigure(write(bad)); !errors.Is(err, pluginapi.ErrInvalidConfig) {
+			t.Fatalf("reconfigure(%q) = %v, want ErrInvalidConfig", bad, err)
+		}
+	}
+	if impl.users["ops"] != "pass" {
+		t.Fatalf("users = %v after rejected configs, want ops=pass", impl.users)
+	}
+}
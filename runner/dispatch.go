@@ -0,0 +1,324 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Dispatcher
+//
+// 模拟主程序调用钩子，交互模式与脚本模式共用
+// 多插件时的执行模型与主程序一致，见 pluginapi/order.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// dispatcher 钩子分发器
+// 钩子持有读锁，热升级持有写锁，升级期间的事件等待升级完成后投递给新实例
+type dispatcher struct {
+	mu         sync.RWMutex
+	plugins    []*loadedPlugin // 按 SortPlugins 排序
+	sequential bool            // OnAuth/OnSubscribe 是否顺序执行
+	host       *recordingHost
+	logLevel   string // -log-level，升级后的新实例沿用
+
+	copyContexts bool // 为每个插件复制 PublishContext 与载荷，声明了 SharedPublish 的插件也不共享（仅用于压测对比）
+	syncHooks    bool // 在当前 goroutine 中调用 OnPublish，不强制超时（-hook-timeouts=false）
+	benchmark    bool // 压测模式（-bench）：不核对共享上下文是否被修改，批量投递不逐批打印，见 setBenchmark
+}
+
+func newDispatcher(plugins []*loadedPlugin, host *recordingHost, logLevel string) *dispatcher {
+	return &dispatcher{plugins: plugins, sequential: pluginapi.Sequential(metasOf(plugins)), host: host, logLevel: logLevel}
+}
+
+// setBenchmark 切换压测模式，同时应用到各插件的发布缓冲（热升级后的新实例见 upgrade）
+func (d *dispatcher) setBenchmark(on bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.benchmark = on
+	for _, lp := range d.plugins {
+		if lp.batch != nil {
+			lp.batch.quiet.Store(on)
+		}
+	}
+}
+
+// metasOf 返回去重后的插件元信息（同一插件的多个实例取第一个），见 loader.Metas
+func metasOf(plugins []*loadedPlugin) []pluginapi.PluginMeta {
+	return loader.Metas(sources(plugins))
+}
+
+// current 返回当前的插件列表
+func (d *dispatcher) current() []*loadedPlugin {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]*loadedPlugin(nil), d.plugins...)
+}
+
+// find 按名称查找插件；name 为空且只加载了一个插件时返回该插件
+func (d *dispatcher) find(name string) *loadedPlugin {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.lookup(name)
+}
+
+// lookup 同 find，调用方持有锁
+func (d *dispatcher) lookup(name string) *loadedPlugin {
+	if name == "" && len(d.plugins) == 1 {
+		return d.plugins[0]
+	}
+	for _, lp := range d.plugins {
+		if lp.Name() == name {
+			return lp
+		}
+	}
+	return nil
+}
+
+// reconfigure 向插件推送新配置，不会与热升级并发
+func (d *dispatcher) reconfigure(lp *loadedPlugin, config []byte) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.lookup(lp.Name()) != lp {
+		return fmt.Errorf("%s: plugin instance was replaced by an upgrade", lp.Name())
+	}
+	return lp.reconfigure(config)
+}
+
+// decision 单个插件的决策结果
+type decision struct {
+	allow bool
+	err   error
+	score int // 插件设置的威胁计分
+}
+
+// decide 依次调用插件的决策钩子并合并结果，返回累加的威胁计分
+// 并行模式下所有插件都会被调用；顺序模式下拒绝或 Final 插件允许即终止
+// 未声明该钩子的插件不调用（视为允许）；超时、panic 或健康检查为不可用时按插件的失败策略处理：
+// FailClosed 拒绝，FailOpen 跳过该插件
+func (d *dispatcher) decide(hook pluginapi.HookMask, call func(lp *loadedPlugin) decision) (bool, int, error) {
+	allow := true
+	score := 0
+	var errs []error
+	for _, lp := range d.plugins {
+		if !lp.Meta.GetHooks().Has(hook) {
+			continue
+		}
+		policy := lp.Meta.GetHookPolicy(hook)
+		var res decision
+		failed := false
+		if lp.unhealthy() {
+			// 不可用的插件不被调用
+			fmt.Printf("  %s: unhealthy, %s without calling the plugin\n", lp.Name(), failureAction(policy.OnFailure))
+			failed = true
+		} else {
+			var err error
+			res, err = callHook(policy.Timeout, func() decision { return call(lp) })
+			if err != nil {
+				fmt.Printf("  %s: %s %v, %s\n", lp.Name(), hookName(hook), err, failureAction(policy.OnFailure))
+				errs = append(errs, fmt.Errorf("%s: %s %w", lp.Name(), hookName(hook), err))
+				failed = true
+			}
+		}
+		if failed {
+			if policy.OnFailure == pluginapi.FailOpen {
+				continue
+			}
+			res = decision{}
+		}
+		if len(d.plugins) > 1 {
+			fmt.Printf("  %s: allow=%v\n", lp.Name(), res.allow)
+		}
+		score += res.score
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", lp.Name(), res.err))
+		}
+		if !res.allow {
+			allow = false
+			if d.sequential {
+				break
+			}
+		} else if d.sequential && lp.Meta.Final {
+			break
+		}
+	}
+	return allow, score, errors.Join(errs...)
+}
+
+// auth 调用 OnAuth，允许时记录客户端上线
+// 命中封禁的连接与主程序一致，直接拒绝且不调用插件
+func (d *dispatcher) auth(ctx *pluginapi.AuthContext) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if ban, ok := d.host.security.refused(ctx.ClientID, ctx.Username, ctx.IP); ok {
+		fmt.Printf("[host] connection refused: %s is banned (%s)\n", ban.Subject, ban.Reason)
+		return false, nil
+	}
+
+	allow, score, err := d.decide(pluginapi.HookAuth, func(lp *loadedPlugin) decision {
+		c := *ctx // 每个插件独立的上下文，威胁分累加
+		ok, err := lp.Impl.OnAuth(&c)
+		return decision{allow: ok, err: err, score: c.ThreatScore}
+	})
+	ctx.ThreatScore = score
+
+	d.reportThreat(ctx.IP, ctx.ThreatScore, "OnAuth")
+	if allow {
+		d.host.clients.connected(ctx.ClientID, ctx.Username, ctx.IP)
+	}
+	d.flushKicked()
+	return allow, err
+}
+
+// subscribe 调用 OnSubscribe，允许时记录订阅
+func (d *dispatcher) subscribe(ctx *pluginapi.SubscribeContext) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	// 非法过滤器由主程序在协议层拒绝，不会到达插件
+	if err := pluginapi.ValidateTopicFilter(ctx.Topic); err != nil {
+		fmt.Printf("[host] subscribe rejected: %v\n", err)
+		return false, err
+	}
+	if ctx.IP == "" {
+		ctx.IP = d.host.clients.ipOf(ctx.ClientID)
+	}
+
+	allow, score, err := d.decide(pluginapi.HookSubscribe, func(lp *loadedPlugin) decision {
+		c := *ctx
+		ok, err := lp.Impl.OnSubscribe(&c)
+		return decision{allow: ok, err: err, score: c.ThreatScore}
+	})
+	ctx.ThreatScore = score
+
+	d.reportThreat(ctx.IP, ctx.ThreatScore, "OnSubscribe")
+	if allow {
+		d.host.clients.subscribed(ctx.ClientID, ctx.Topic, ctx.QoS)
+	}
+	d.flushKicked()
+	return allow, err
+}
+
+// publish 调用 OnPublish（跳过未声明该钩子或不可用的插件），之后消息计入 Broker 状态
+func (d *dispatcher) publish(ctx *pluginapi.PublishContext) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	d.deliverPublish(ctx)
+	d.host.broker.routed(pluginapi.Message{Topic: ctx.Topic, Payload: ctx.Payload, QoS: ctx.QoS, Retain: ctx.Retain})
+	d.flushKicked()
+}
+
+// dispatchPublish 同 publish，但不计入 Broker 状态，供压测只统计分发开销
+func (d *dispatcher) dispatchPublish(ctx *pluginapi.PublishContext) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	d.deliverPublish(ctx)
+	d.flushKicked()
+}
+
+// deliverPublish 将消息投递给插件，不计入 Broker 状态（调用方持有读锁）
+// 与主程序一致，声明了 SharedPublish 的插件共享同一个池化的只读上下文，全部返回后回收，其他插件收到独立副本；
+// PublishFilter 在调用前判断。超时或 panic 的调用被跳过，不影响其他插件；共享上下文的调用超时时上下文不回收
+func (d *dispatcher) deliverPublish(ctx *pluginapi.PublishContext) {
+	pooled := acquirePublish(ctx)
+	shared := &pooled.ctx
+	reusable := true
+	for _, lp := range d.plugins {
+		if !lp.Meta.GetHooks().Has(pluginapi.HookPublish) || lp.unhealthy() {
+			continue
+		}
+		if f := lp.Meta.PublishFilter; f != nil && (!f.Match(ctx.Topic, ctx.QoS) || !f.Sampled(lp.matched.Add(1)-1)) {
+			continue
+		}
+		if lp.batch != nil {
+			lp.batch.add(*shared.Clone()) // BatchPublisher 按批投递，缓冲中的消息需要独立副本，见 batch.go
+			continue
+		}
+		c := shared
+		if d.copyContexts || !lp.Meta.SharedPublish {
+			c = ctx.Clone()
+		}
+		lp.delivered.Add(1)
+		if d.syncHooks {
+			d.notifyPublishSync(lp, c)
+		} else if !d.notify(lp, pluginapi.HookPublish, func() { lp.Impl.OnPublish(c) }) {
+			reusable = reusable && c != shared
+			continue
+		}
+		if c == shared && !d.benchmark {
+			checkShared(lp, shared, ctx)
+		}
+	}
+	if reusable {
+		pooled.release()
+	}
+}
+
+// notifyPublishSync 在当前 goroutine 中调用 OnPublish（-hook-timeouts=false）
+// 不创建 goroutine、通道与定时器，调用返回后才报告超时，上下文总能回收
+func (d *dispatcher) notifyPublishSync(lp *loadedPlugin, c *pluginapi.PublishContext) {
+	timeout := lp.Meta.GetHookPolicy(pluginapi.HookPublish).Timeout
+	start := time.Now()
+	defer func() {
+		if v := recover(); v != nil {
+			fmt.Printf("  %s: OnPublish panic: %v, skipped\n", lp.Name(), v)
+		} else if elapsed := time.Since(start); elapsed > timeout {
+			fmt.Printf("  %s: OnPublish took %v, exceeds timeout %v\n", lp.Name(), elapsed, timeout)
+		}
+	}()
+	lp.Impl.OnPublish(c)
+}
+
+// disconnect 调用 OnDisconnect 并标记客户端离线
+func (d *dispatcher) disconnect(ctx *pluginapi.DisconnectContext) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	d.host.clients.disconnected(ctx.ClientID)
+	d.notifyDisconnect(ctx)
+	d.flushKicked()
+}
+
+func (d *dispatcher) notifyDisconnect(ctx *pluginapi.DisconnectContext) {
+	for _, lp := range d.plugins {
+		if !lp.Meta.GetHooks().Has(pluginapi.HookDisconnect) || lp.unhealthy() {
+			continue
+		}
+		c := *ctx
+		d.notify(lp, pluginapi.HookDisconnect, func() { lp.Impl.OnDisconnect(&c) })
+	}
+}
+
+// notify 调用通知型钩子，超时或 panic 时跳过该插件
+// 返回 false 表示调用超时仍在执行，上下文不能回收
+func (d *dispatcher) notify(lp *loadedPlugin, hook pluginapi.HookMask, call func()) bool {
+	timeout := lp.Meta.GetHookPolicy(hook).Timeout
+	if _, err := callHook(timeout, func() struct{} { call(); return struct{}{} }); err != nil {
+		fmt.Printf("  %s: %s %v, skipped\n", lp.Name(), hookName(hook), err)
+		return !errors.Is(err, errHookTimedOut)
+	}
+	return true
+}
+
+// reportThreat 将钩子设置的 ThreatScore 累加到 IP
+func (d *dispatcher) reportThreat(ip string, score int, hook string) {
+	if score <= 0 || ip == "" {
+		return
+	}
+	d.host.security.ReportThreat(pluginapi.IPSubject(ip), score, hook)
+}
+
+// flushKicked 为被插件踢下线的客户端触发 OnDisconnect
+// 与主程序一致，在触发踢人的钩子返回后才调用（调用方持有读锁）
+func (d *dispatcher) flushKicked() {
+	for {
+		kicked := d.host.clients.takeKicked()
+		if len(kicked) == 0 {
+			return
+		}
+		for i := range kicked {
+			d.notifyDisconnect(&kicked[i])
+		}
+	}
+}
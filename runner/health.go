@@ -0,0 +1,100 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Health Check
+//
+// 调用插件的 HealthChecker 并按主程序的策略绕过不可用的插件
+// 主程序每 HealthCheckInterval 轮询一次；调试器在每个测试用例与交互命令之后检查，结果可复现
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// checkHealth 调用插件的 Health，更新缓存与健康指标
+// 未实现 HealthChecker 的插件返回 ok=false；状态变化时 changed=true
+func (lp *loadedPlugin) checkHealth() (h pluginapi.Health, ok, changed bool) {
+	hc, ok := lp.Impl.(pluginapi.HealthChecker)
+	if !ok {
+		return pluginapi.Health{}, false, false
+	}
+
+	result := make(chan pluginapi.Health, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				result <- pluginapi.Health{Status: pluginapi.HealthUnhealthy, Message: fmt.Sprintf("health check panic: %v", v)}
+			}
+		}()
+		result <- hc.Health()
+	}()
+	select {
+	case h = <-result:
+	case <-time.After(pluginapi.HealthCheckTimeout):
+		h = pluginapi.Health{Status: pluginapi.HealthUnhealthy, Message: "health check timed out"}
+	}
+
+	prev := lp.health.Swap(&h)
+	changed = prev == nil || prev.Status != h.Status
+	g, err := lp.host.hostMetrics.Gauge(pluginapi.HealthMetric, "Plugin health (0=healthy, 1=degraded, 2=unhealthy)", nil)
+	if err != nil {
+		fmt.Printf("[health] %s: cannot export %s%s: %v\n", lp.Name(), pluginapi.MetricPrefix, pluginapi.HealthMetric, err)
+	} else {
+		g.Set(float64(h.Status))
+	}
+	return h, true, changed
+}
+
+// unhealthy 最近一次检查结果是否为不可用（主程序将绕过该插件）
+func (lp *loadedPlugin) unhealthy() bool {
+	h := lp.health.Load()
+	return h != nil && h.Status == pluginapi.HealthUnhealthy
+}
+
+// checkHealth 检查所有插件，onlyChanged 为 true 时只打印状态变化
+func (d *dispatcher) checkHealth(onlyChanged bool) {
+	for _, lp := range d.current() {
+		h, ok, changed := lp.checkHealth()
+		if ok && (changed || !onlyChanged) {
+			fmt.Printf("[health] %s: %s\n", lp.Name(), formatHealth(h))
+		}
+	}
+}
+
+// formatHealth 格式化健康状态与附加信息
+func formatHealth(h pluginapi.Health) string {
+	s := h.Status.String()
+	if h.Message != "" {
+		s += " (" + h.Message + ")"
+	}
+	if len(h.Details) > 0 {
+		keys := make([]string, 0, len(h.Details))
+		for k := range h.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + "=" + h.Details[k]
+		}
+		s += " " + strings.Join(parts, ", ")
+	}
+	return s
+}
+
+func handleHealth(d *dispatcher) {
+	found := false
+	for _, lp := range d.current() {
+		if h, ok, _ := lp.checkHealth(); ok {
+			fmt.Printf("%s: %s\n", lp.Name(), formatHealth(h))
+			found = true
+		}
+	}
+	if !found {
+		fmt.Println("No plugin implements HealthChecker")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Host API
+//
+// 本地模拟的主程序 API，记录插件调用并打印到控制台
+// 客户端、Broker 状态、封禁、指标、服务注册表、集群视图等状态由所有插件共享，日志、存储、任务按插件隔离
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/cluster"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/kvstore"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/metrics"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/scheduler"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/services"
+)
+
+// recordingHost 所有插件共享的主程序状态
+type recordingHost struct {
+	mu        sync.Mutex
+	published []pluginapi.Message
+	clients   *memClients
+	broker    *memBroker
+	security  *localSecurity
+	logs      *logSink
+	registry  *metrics.Registry
+	storeFS   *kvstore.FileStore
+	services  *services.Registry
+	cluster   *cluster.Membership
+}
+
+func newRecordingHost(storeFS *kvstore.FileStore, membership *cluster.Membership) *recordingHost {
+	clients := newMemClients()
+	return &recordingHost{
+		clients:  clients,
+		broker:   newMemBroker(clients),
+		security: newLocalSecurity(clients),
+		logs:     &logSink{},
+		registry: metrics.NewRegistry(),
+		storeFS:  storeFS,
+		services: services.New(),
+		cluster:  membership,
+	}
+}
+
+// forPlugin 创建插件实例专用的 HostAPI，instance 为空表示默认实例
+// 存储、服务与任务按完整名称隔离，日志与指标另带 instance 属性；level 可在配置更新后调整
+func (h *recordingHost) forPlugin(plugin, instance string, level *slog.LevelVar) (*pluginHost, error) {
+	name := pluginapi.InstanceName(plugin, instance)
+	store, err := h.storeFS.Namespace(name)
+	if err != nil {
+		return nil, err
+	}
+	logger := slog.New(newCaptureHandler(h.logs, level)).With(pluginapi.LogKeyPlugin, plugin)
+	if instance != "" {
+		logger = logger.With(pluginapi.LogKeyInstance, instance)
+	}
+	return &pluginHost{
+		recordingHost: h,
+		name:          name,
+		instance:      instance,
+		level:         level,
+		logger:        logger,
+		metrics:       h.registry.ForInstance(plugin, instance),
+		hostMetrics:   h.registry.ForHost(plugin, instance),
+		store:         store,
+		scheduler: scheduler.New(func(task string, v any) {
+			fmt.Printf("[host] %s: task %s panic: %v\n", name, task, v)
+		}),
+		serviceView: h.services.ForPlugin(name),
+	}, nil
+}
+
+// Clients 返回内存版 ClientManager
+func (h *recordingHost) Clients() pluginapi.ClientManager {
+	return h.clients
+}
+
+// Security 返回本地版 SecurityManager
+func (h *recordingHost) Security() pluginapi.SecurityManager {
+	return h.security
+}
+
+// Broker 返回模拟的 Broker 状态
+func (h *recordingHost) Broker() pluginapi.BrokerState {
+	return h.broker
+}
+
+// Cluster 返回模拟的集群视图
+func (h *recordingHost) Cluster() pluginapi.Cluster {
+	return h.cluster
+}
+
+// Publish 记录并打印插件发布的消息
+func (h *recordingHost) Publish(msg *pluginapi.Message) error {
+	if msg == nil {
+		return pluginapi.ErrInvalidTopic
+	}
+	if err := msg.Validate(); err != nil {
+		fmt.Printf("[host] publish rejected: %v\n", err)
+		return err
+	}
+
+	m := *msg
+	m.Payload = append([]byte(nil), msg.Payload...)
+
+	h.mu.Lock()
+	h.published = append(h.published, m)
+	h.mu.Unlock()
+	h.broker.routed(m)
+
+	fmt.Printf("[host] publish: topic=%s, qos=%d, retain=%v, payload=%q\n",
+		m.Topic, m.QoS, m.Retain, m.Payload)
+	if p := m.Properties; p != nil {
+		fmt.Printf("[host]   properties: format=%d, expiry=%d, content_type=%q, response_topic=%q, user=%v\n",
+			p.PayloadFormat, p.MessageExpiry, p.ContentType, p.ResponseTopic, p.UserProperties)
+	}
+	return nil
+}
+
+// Published 返回已记录的消息
+func (h *recordingHost) Published() []pluginapi.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]pluginapi.Message(nil), h.published...)
+}
+
+// pluginHost 单个插件看到的 HostAPI
+type pluginHost struct {
+	*recordingHost
+	name        string // 完整名称，见 pluginapi.InstanceName
+	instance    string
+	level       *slog.LevelVar
+	logger      *slog.Logger
+	metrics     pluginapi.Metrics
+	hostMetrics pluginapi.Metrics // 主程序为插件导出的指标，见 metrics.Registry.ForHost
+	store       pluginapi.KVStore
+	scheduler   *scheduler.Scheduler
+	serviceView pluginapi.ServiceRegistry
+}
+
+var _ pluginapi.HostAPI = (*pluginHost)(nil)
+
+// Logger 返回捕获型日志
+func (h *pluginHost) Logger() *slog.Logger {
+	return h.logger
+}
+
+// Instance 返回实例名
+func (h *pluginHost) Instance() string {
+	return h.instance
+}
+
+// Metrics 返回插件指标注册表
+func (h *pluginHost) Metrics() pluginapi.Metrics {
+	return h.metrics
+}
+
+// Store 返回文件版 KVStore
+func (h *pluginHost) Store() pluginapi.KVStore {
+	return h.store
+}
+
+// Scheduler 返回托管任务调度器
+func (h *pluginHost) Scheduler() pluginapi.Scheduler {
+	return h.scheduler
+}
+
+// Services 返回插件间服务注册表
+func (h *pluginHost) Services() pluginapi.ServiceRegistry {
+	return h.serviceView
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Logger
+//
+// 捕获插件通过 HostAPI.Logger 输出的日志，打印到控制台并供测试脚本断言
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// logEntry 捕获的一条日志
+type logEntry struct {
+	Level slog.Level
+	Text  string // 消息与属性的文本形式
+}
+
+// logSink 日志存储，多个 handler 共享
+type logSink struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (s *logSink) add(e logEntry) {
+	s.mu.Lock()
+	s.entries = append(s.entries, e)
+	s.mu.Unlock()
+}
+
+// len 返回已捕获的日志条数
+func (s *logSink) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// since 返回第 n 条之后的日志
+func (s *logSink) since(n int) []logEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n >= len(s.entries) {
+		return nil
+	}
+	return append([]logEntry(nil), s.entries[n:]...)
+}
+
+// captureHandler slog.Handler 实现
+type captureHandler struct {
+	sink   *logSink
+	level  slog.Leveler
+	attrs  string // 预置属性的文本形式
+	prefix string // 当前分组前缀
+}
+
+var _ slog.Handler = (*captureHandler)(nil)
+
+func newCaptureHandler(sink *logSink, level slog.Leveler) *captureHandler {
+	return &captureHandler{sink: sink, level: level}
+}
+
+func (h *captureHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.prefix, a)
+		return true
+	})
+
+	text := b.String()
+	h.sink.add(logEntry{Level: r.Level, Text: text})
+	fmt.Printf("[log] %-5s %s\n", r.Level, text)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		writeAttr(&b, h.prefix, a)
+	}
+	h2 := *h
+	h2.attrs = b.String()
+	return &h2
+}
+
+func (h *captureHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		p := prefix
+		if a.Key != "" {
+			p += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, p, ga)
+		}
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", prefix, a.Key, a.Value.Any())
+}
+
+// LogExpect 测试脚本中的日志断言
+type LogExpect struct {
+	Level    string `json:"level,omitempty"` // 日志级别（debug/info/warn/error，可选）
+	Contains string `json:"contains"`        // 日志文本应包含的内容
+}
+
+// matchLogs 检查日志是否满足断言，返回未满足的断言
+func matchLogs(entries []logEntry, expects []LogExpect) []LogExpect {
+	var missing []LogExpect
+	for _, want := range expects {
+		var level slog.Level
+		checkLevel := want.Level != "" && level.UnmarshalText([]byte(want.Level)) == nil
+		found := false
+		for _, e := range entries {
+			if checkLevel && e.Level != level {
+				continue
+			}
+			if strings.Contains(e.Text, want.Contains) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+	return missing
+}
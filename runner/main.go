@@ -4,8 +4,15 @@
 // 本地调试运行器，让开发者无需运行完整 AXMQ 即可测试插件
 //
 // 使用方法：
-//   go run runner/main.go -plugin ./my_plugin.so
-//   go run runner/main.go -plugin ./my_plugin.so -script testcases.json
+//   go run ./runner -plugin ./my_plugin.so
+//   go run ./runner -plugin ./my_plugin.so -script testcases.json
+//   go run ./runner -plugin ./my_plugin.so -bench 100000 -metrics-addr :9100
+//   go run ./runner -plugin ./directory.so,./acl.so -config ./directory.json,./acl.json
+//   go run ./runner -plugin ./report.so -nodes 3 -node node-2
+//   go run ./runner -plugin ./my_plugin.so -config ./my_plugin.json -watch
+//   go run ./runner -plugin ./webhook.so,./webhook.so -config ./webhook@billing.json,./webhook@audit.json
+//   go run ./runner -plugin-dir ./data/plugins -require-meta
+//   go run ./runner -plugin ./my_plugin_v1.so -bench 100000 -upgrade ./my_plugin_v2.so
 
 package main
 
@@ -15,96 +22,189 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"plugin"
+	"runtime"
 	"strings"
+	"time"
 
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
 	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
 )
 
 var (
-	pluginPath = flag.String("plugin", "", "Path to plugin .so file")
-	scriptPath = flag.String("script", "", "Path to test script JSON file (optional)")
-	configPath = flag.String("config", "", "Path to plugin config file (optional)")
+	pluginPath  = flag.String("plugin", "", "Path to plugin .so file (comma-separated for multiple plugins)")
+	scriptPath  = flag.String("script", "", "Path to test script JSON file (optional)")
+	configPath  = flag.String("config", "", "Path to plugin config file (optional, comma-separated in the same order as -plugin; name@instance.json loads a named instance)")
+	logLevel    = flag.String("log-level", "", "Plugin log level: debug/info/warn/error (default: log_level in config, or info)")
+	watch       = flag.Bool("watch", false, "Push config file edits into the loaded plugins via Reconfigure")
+	hostVersion = flag.String("host-version", "", "AXMQ version to check against min_host_version/max_host_version (optional)")
+	pluginDir   = flag.String("plugin-dir", "", "Load every plugin in a directory with its configs, as AXMQ does (instead of -plugin/-config)")
+	requireMeta = flag.Bool("require-meta", false, "Refuse plugins without a .meta.json, as AXMQ does")
+
+	benchCount   = flag.Int("bench", 0, "Run OnPublish benchmark with N messages (optional)")
+	benchTopic   = flag.String("bench-topic", "bench/test", "Topic used by the benchmark")
+	benchPayload = flag.Int("bench-payload", 64, "Payload size in bytes used by the benchmark")
+	upgradePath  = flag.String("upgrade", "", "Hot-upgrade to this .so halfway through the -bench run (optional)")
+	benchCompare = flag.Bool("bench-compare", false, "Run -bench twice, copying contexts per plugin and with pooled shared contexts, and compare dispatch cost")
+	hookTimeouts = flag.Bool("hook-timeouts", true, "Enforce OnPublish timeouts with a goroutine per call; false calls OnPublish synchronously and reports overruns afterwards")
+	metricsAddr  = flag.String("metrics-addr", "", "Serve plugin metrics at http://<addr>/metrics (optional)")
+	storeDir     = flag.String("store-dir", "", "Directory for the plugin key-value store (default: temporary, removed on exit)")
+
+	clusterNodes  = flag.Int("nodes", 1, "Simulate a cluster of N nodes named node-1 ... node-N")
+	clusterNode   = flag.String("node", "", "Node the plugins run on (default: node-1)")
+	clusterLeader = flag.String("leader", "", "Initial cluster leader (default: node-1)")
 )
 
 func main() {
 	flag.Parse()
 
-	if *pluginPath == "" {
-		fmt.Println("Usage: go run runner/main.go -plugin <path/to/plugin.so>")
+	if (*pluginPath == "") == (*pluginDir == "") {
+		fmt.Println("Usage: go run ./runner -plugin <path/to/plugin.so>[,<another.so>...] | -plugin-dir <dir>")
 		os.Exit(1)
 	}
 
-	// 加载插件
-	plug, err := loadPlugin(*pluginPath)
+	// -plugin-dir 按主程序的规则扫描目录；否则配置与 -plugin 按位置对应，配置文件名带 "@<实例名>" 时加载为命名实例
+	var specs []loader.Spec
+	var err error
+	if *pluginDir != "" {
+		specs, err = loader.Discover(*pluginDir)
+	} else {
+		specs, err = pluginSpecs(splitList(*pluginPath), splitList(*configPath))
+	}
 	if err != nil {
-		fmt.Printf("Failed to load plugin: %v\n", err)
+		fmt.Printf("Invalid plugin or config: %v\n", err)
 		os.Exit(1)
 	}
-	defer plug.Close()
 
-	// 显示插件信息
-	info := plug.Info()
-	fmt.Printf("Plugin loaded successfully:\n")
-	fmt.Printf("  Name:        %s\n", info.Name)
-	fmt.Printf("  Version:     %s\n", info.Version)
-	fmt.Printf("  SDK Version: %s\n", info.SDKVersion)
-	fmt.Printf("  Go Version:  %s\n", info.GoVersion)
-	fmt.Printf("  Build Time:  %s\n", info.BuildTime)
-	fmt.Println()
+	// 加载插件（与主程序相同的流程，按执行顺序排序，拒绝不兼容或依赖不满足的插件）
+	plugins, err := loadPlugins(specs)
+	if err != nil {
+		fmt.Printf("Failed to load plugin: %v\n", err)
+		os.Exit(1)
+	}
+	for _, lp := range plugins {
+		lp.printInfo()
+	}
 
-	// 初始化插件
-	var config []byte
-	if *configPath != "" {
-		config, err = os.ReadFile(*configPath)
+	// 读取配置（以实例的完整名称为键）
+	configs := make(map[string][]byte)
+	for _, lp := range plugins {
+		if lp.ConfigPath == "" {
+			continue
+		}
+		config, err := os.ReadFile(lp.ConfigPath)
 		if err != nil {
 			fmt.Printf("Warning: failed to read config file: %v\n", err)
+			continue
 		}
+		configs[lp.Name()] = config
 	}
-	if err := plug.Init(config); err != nil {
-		fmt.Printf("Plugin initialization failed: %v\n", err)
+
+	storeFS, cleanup, err := openStore(*storeDir)
+	if err != nil {
+		fmt.Printf("Failed to open store: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println("Plugin initialized.")
+	defer cleanup()
 
-	// 如果指定了测试脚本，执行脚本
-	if *scriptPath != "" {
-		runScript(plug, *scriptPath)
-		return
+	membership, err := newCluster(*clusterNodes, *clusterNode, *clusterLeader)
+	if err != nil {
+		fmt.Printf("Invalid cluster settings: %v\n", err)
+		cleanup()
+		os.Exit(1)
+	}
+	if *clusterNodes > 1 {
+		printCluster(membership)
 	}
 
-	// 交互式模式
-	runInteractive(plug)
-}
+	host := newRecordingHost(storeFS, membership)
+	if *metricsAddr != "" {
+		serveMetrics(host, *metricsAddr)
+	}
 
-func loadPlugin(path string) (pluginapi.Plugin, error) {
-	p, err := plugin.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open plugin: %w", err)
+	// 依赖优先初始化，关闭时逆序
+	baseGoroutines := runtime.NumGoroutine()
+	var initialized []*loadedPlugin
+	var d *dispatcher
+	defer func() {
+		if d != nil {
+			initialized = d.current() // 热升级后为新实例
+		}
+		shutdownPlugins(initialized, host, baseGoroutines)
+	}()
+	for _, lp := range initOrder(plugins) {
+		if err := lp.init(host, configs[lp.Name()], *logLevel); err != nil {
+			fmt.Printf("Plugin %s initialization failed: %v\n", lp.Name(), err)
+			shutdownPlugins(initialized, host, baseGoroutines)
+			cleanup()
+			os.Exit(1)
+		}
+		initialized = append(initialized, lp)
+		fmt.Printf("Plugin %s initialized.\n", lp.Name())
 	}
 
-	sym, err := p.Lookup("NewPlugin")
-	if err != nil {
-		return nil, pluginapi.ErrSymbolNotFound
+	d = newDispatcher(plugins, host, *logLevel)
+	d.syncHooks = !*hookTimeouts
+	d.setBenchmark(*benchCount > 0)
+	d.checkHealth(false)
+	if *watch {
+		stop := watchConfigs(d, configs)
+		defer stop()
+	}
+	if len(plugins) > 1 {
+		mode := "parallel"
+		if d.sequential {
+			mode = "sequential"
+		}
+		fmt.Printf("Execution order (%s): %s\n", mode, strings.Join(pluginNames(plugins), " -> "))
+	}
+
+	if *upgradePath != "" && *benchCount == 0 {
+		fmt.Println("Warning: -upgrade only applies to -bench; use the 'upgrade' command or script hook otherwise")
 	}
 
-	newFunc, ok := sym.(func() pluginapi.Plugin)
-	if !ok {
-		return nil, pluginapi.ErrInvalidPluginType
+	// 如果指定了测试脚本或压测，执行后输出指标
+	if *scriptPath != "" || *benchCount > 0 {
+		if *scriptPath != "" {
+			runScript(d, *scriptPath)
+		}
+		if *benchCount > 0 && *benchCompare {
+			compareBench(d, *benchCount, *benchTopic, *benchPayload)
+		} else if *benchCount > 0 {
+			runBench(d, *benchCount, *benchTopic, *benchPayload, *upgradePath)
+		}
+		printMetrics(host)
+		if *metricsAddr != "" {
+			fmt.Println("Press Ctrl+C to exit.")
+			waitSignal()
+		}
+		return
 	}
 
-	plug := newFunc()
+	// 交互式模式
+	runInteractive(d)
+}
 
-	// 校验元信息
-	info := plug.Info()
-	if err := info.Validate(); err != nil {
-		return nil, err
+// splitList 拆分逗号分隔的参数
+func splitList(s string) []string {
+	if s == "" {
+		return nil
 	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
 
-	return plug, nil
+func pluginNames(plugins []*loadedPlugin) []string {
+	names := make([]string, len(plugins))
+	for i, lp := range plugins {
+		names[i] = lp.Name()
+	}
+	return names
 }
 
-func runInteractive(plug pluginapi.Plugin) {
+func runInteractive(d *dispatcher) {
 	fmt.Println("Interactive mode. Type 'help' for available commands.")
 	fmt.Println()
 
@@ -127,19 +227,49 @@ func runInteractive(plug pluginapi.Plugin) {
 		case "help":
 			printHelp()
 		case "auth":
-			handleAuth(plug, parts[1:])
+			handleAuth(d, parts[1:])
 		case "subscribe":
-			handleSubscribe(plug, parts[1:])
+			handleSubscribe(d, parts[1:])
 		case "publish":
-			handlePublish(plug, parts[1:])
+			handlePublish(d, parts[1:])
+		case "flush":
+			d.flushBatches("flush")
 		case "disconnect":
-			handleDisconnect(plug, parts[1:])
+			handleDisconnect(d, parts[1:])
+		case "published":
+			handlePublished(d.host)
+		case "clients":
+			handleClients(d.host)
+		case "broker":
+			handleBroker(d.host, parts[1:])
+		case "metrics":
+			printMetrics(d.host)
+		case "tasks":
+			handleTasks(d)
+		case "services":
+			handleServices(d.host)
+		case "kv":
+			handleKV(d, parts[1:])
+		case "cluster":
+			handleCluster(d.host, parts[1:])
+		case "reconfigure":
+			handleReconfigure(d, parts[1:])
+		case "upgrade":
+			handleUpgrade(d, parts[1:])
+		case "bans":
+			handleBans(d.host)
+		case "score":
+			handleScore(d.host, parts[1:])
+		case "health":
+			handleHealth(d)
+			continue
 		case "exit", "quit":
 			fmt.Println("Bye!")
 			return
 		default:
 			fmt.Printf("Unknown command: %s. Type 'help' for available commands.\n", cmd)
 		}
+		d.checkHealth(true)
 	}
 }
 
@@ -151,13 +281,41 @@ func printHelp() {
 	fmt.Println("    Test OnSubscribe hook")
 	fmt.Println("  publish <clientID> <username> <topic> <payload> <qos> [retain]")
 	fmt.Println("    Test OnPublish hook")
+	fmt.Println("  flush")
+	fmt.Println("    Deliver messages buffered for OnPublishBatch without waiting for the linger time")
 	fmt.Println("  disconnect <clientID> <username> [reason]")
 	fmt.Println("    Test OnDisconnect hook")
+	fmt.Println("  published")
+	fmt.Println("    List messages published by plugins via HostAPI")
+	fmt.Println("  clients")
+	fmt.Println("    List clients tracked by the runner and their subscriptions")
+	fmt.Println("  broker [filter]")
+	fmt.Println("    Show $SYS statistics, or topics matching a filter with subscriber counts and retained messages")
+	fmt.Println("  metrics")
+	fmt.Println("    Show plugin metrics in Prometheus text format")
+	fmt.Println("  tasks")
+	fmt.Println("    List running tasks started via HostAPI.Scheduler()")
+	fmt.Println("  services")
+	fmt.Println("    List services registered by plugins")
+	fmt.Println("  kv [prefix]")
+	fmt.Println("    List entries in the plugins' key-value stores")
+	fmt.Println("  cluster [join <node> | leave <node> | leader [node]]")
+	fmt.Println("    Show the simulated cluster, or change membership/leadership (leader without node starts an election)")
+	fmt.Println("  reconfigure [plugin[@instance]]")
+	fmt.Println("    Re-read the plugin's config file and push it via Reconfigure")
+	fmt.Println("  upgrade <path/to/new.so>")
+	fmt.Println("    Hot-upgrade every instance of the plugin with the same name, handing over state via Snapshotter")
+	fmt.Println("  health")
+	fmt.Println("    Show the health reported by plugins implementing HealthChecker")
+	fmt.Println("  bans")
+	fmt.Println("    List active bans")
+	fmt.Println("  score <kind:value>")
+	fmt.Println("    Show threat score, e.g. score ip:192.168.1.1")
 	fmt.Println("  exit / quit")
 	fmt.Println("    Exit the runner")
 }
 
-func handleAuth(plug pluginapi.Plugin, args []string) {
+func handleAuth(d *dispatcher, args []string) {
 	if len(args) < 4 {
 		fmt.Println("Usage: auth <clientID> <username> <password> <ip>")
 		return
@@ -170,14 +328,14 @@ func handleAuth(plug pluginapi.Plugin, args []string) {
 		IP:       args[3],
 	}
 
-	allow, err := plug.OnAuth(ctx)
+	allow, err := d.auth(ctx)
 	if err != nil {
 		fmt.Printf("OnAuth error: %v\n", err)
 	}
 	fmt.Printf("OnAuth result: allow=%v\n", allow)
 }
 
-func handleSubscribe(plug pluginapi.Plugin, args []string) {
+func handleSubscribe(d *dispatcher, args []string) {
 	if len(args) < 4 {
 		fmt.Println("Usage: subscribe <clientID> <username> <topic> <qos>")
 		return
@@ -193,14 +351,14 @@ func handleSubscribe(plug pluginapi.Plugin, args []string) {
 		QoS:      qos,
 	}
 
-	allow, err := plug.OnSubscribe(ctx)
+	allow, err := d.subscribe(ctx)
 	if err != nil {
 		fmt.Printf("OnSubscribe error: %v\n", err)
 	}
 	fmt.Printf("OnSubscribe result: allow=%v\n", allow)
 }
 
-func handlePublish(plug pluginapi.Plugin, args []string) {
+func handlePublish(d *dispatcher, args []string) {
 	if len(args) < 5 {
 		fmt.Println("Usage: publish <clientID> <username> <topic> <payload> <qos> [retain]")
 		return
@@ -223,11 +381,21 @@ func handlePublish(plug pluginapi.Plugin, args []string) {
 		Retain:   retain,
 	}
 
-	plug.OnPublish(ctx)
+	plugins := d.current()
+	before := make([]uint64, len(plugins))
+	for i, lp := range plugins {
+		before[i] = lp.delivered.Load()
+	}
+	d.publish(ctx)
+	for i, lp := range plugins {
+		if lp.Meta.PublishFilter != nil && !lp.unhealthy() && lp.delivered.Load() == before[i] {
+			fmt.Printf("  %s: skipped by publish_filter\n", lp.Name())
+		}
+	}
 	fmt.Println("OnPublish called (async hook, no return value)")
 }
 
-func handleDisconnect(plug pluginapi.Plugin, args []string) {
+func handleDisconnect(d *dispatcher, args []string) {
 	if len(args) < 2 {
 		fmt.Println("Usage: disconnect <clientID> <username> [reason]")
 		return
@@ -244,22 +412,148 @@ func handleDisconnect(plug pluginapi.Plugin, args []string) {
 		Reason:   reason,
 	}
 
-	plug.OnDisconnect(ctx)
+	d.disconnect(ctx)
 	fmt.Println("OnDisconnect called")
 }
 
+func handlePublished(host *recordingHost) {
+	msgs := host.Published()
+	if len(msgs) == 0 {
+		fmt.Println("No messages published")
+		return
+	}
+	for i, m := range msgs {
+		fmt.Printf("[%d] topic=%s, qos=%d, retain=%v, payload=%q\n", i+1, m.Topic, m.QoS, m.Retain, m.Payload)
+	}
+}
+
+func handleClients(host *recordingHost) {
+	infos := host.clients.list()
+	if len(infos) == 0 {
+		fmt.Println("No clients")
+		return
+	}
+	for _, info := range infos {
+		subs, _ := host.clients.Subscriptions(info.ClientID)
+		fmt.Printf("%s: user=%s, ip=%s, online=%v, subscriptions=%v\n",
+			info.ClientID, info.Username, info.IP, info.Online, subs)
+	}
+}
+
+func handleTasks(d *dispatcher) {
+	found := false
+	for _, lp := range d.current() {
+		for _, name := range lp.host.scheduler.Running() {
+			fmt.Printf("%s: %s\n", lp.Name(), name)
+			found = true
+		}
+	}
+	if !found {
+		fmt.Println("No running tasks")
+	}
+}
+
+func handleServices(host *recordingHost) {
+	list := host.services.Services()
+	if len(list) == 0 {
+		fmt.Println("No services registered")
+		return
+	}
+	for _, svc := range list {
+		fmt.Printf("%s: version=%s, provider=%s\n", svc.Name, svc.Version, svc.Provider)
+	}
+}
+
+func handleKV(d *dispatcher, args []string) {
+	prefix := ""
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+	found := false
+	for _, lp := range d.current() {
+		kvs, err := lp.host.store.Scan(prefix)
+		if err != nil {
+			fmt.Printf("%s: scan failed: %v\n", lp.Name(), err)
+			continue
+		}
+		for _, kv := range kvs {
+			expires := "never"
+			if !kv.ExpiresAt.IsZero() {
+				expires = kv.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s: %s = %q (expires=%s)\n", lp.Name(), kv.Key, kv.Value, expires)
+			found = true
+		}
+	}
+	if !found {
+		fmt.Println("No entries")
+	}
+}
+
+func handleReconfigure(d *dispatcher, args []string) {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	lp := d.find(name)
+	if lp == nil {
+		fmt.Println("Usage: reconfigure <plugin[@instance]>")
+		return
+	}
+	if lp.ConfigPath == "" {
+		fmt.Printf("%s: no config file (-config)\n", lp.Name())
+		return
+	}
+	config, err := os.ReadFile(lp.ConfigPath)
+	if err != nil {
+		fmt.Printf("Failed to read config file: %v\n", err)
+		return
+	}
+	reportReconfigure(lp, d.reconfigure(lp, config))
+}
+
+func handleBans(host *recordingHost) {
+	bans := host.security.Bans()
+	if len(bans) == 0 {
+		fmt.Println("No active bans")
+		return
+	}
+	for _, ban := range bans {
+		expires := "never"
+		if !ban.Permanent() {
+			expires = ban.ExpiresAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%s: reason=%q, expires=%s\n", ban.Subject, ban.Reason, expires)
+	}
+}
+
+func handleScore(host *recordingHost, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Usage: score <kind:value>")
+		return
+	}
+	subject, err := pluginapi.ParseSubject(args[0])
+	if err != nil {
+		fmt.Printf("Invalid subject: %v\n", err)
+		return
+	}
+	fmt.Printf("%s: score=%d\n", subject, host.security.ThreatScore(subject))
+}
+
 // TestCase 测试用例结构
 type TestCase struct {
 	Name   string          `json:"name"`
 	Hook   string          `json:"hook"`
 	Input  json.RawMessage `json:"input"`
 	Expect struct {
-		Allow *bool  `json:"allow,omitempty"`
-		Error string `json:"error,omitempty"`
+		Allow  *bool       `json:"allow,omitempty"`
+		Error  string      `json:"error,omitempty"`
+		Banned []string    `json:"banned,omitempty"` // 执行后应处于封禁中的对象，如 "ip:10.0.0.1"
+		Logs   []LogExpect `json:"logs,omitempty"`   // 执行期间插件应输出的日志
 	} `json:"expect"`
 }
 
-func runScript(plug pluginapi.Plugin, path string) {
+func runScript(d *dispatcher, path string) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		fmt.Printf("Failed to read script file: %v\n", err)
@@ -280,48 +574,115 @@ func runScript(plug pluginapi.Plugin, path string) {
 
 		var result bool
 		var resultErr error
+		logMark := d.host.logs.len()
 
 		switch tc.Hook {
 		case "auth":
 			var ctx pluginapi.AuthContext
 			json.Unmarshal(tc.Input, &ctx)
-			result, resultErr = plug.OnAuth(&ctx)
+			result, resultErr = d.auth(&ctx)
 		case "subscribe":
 			var ctx pluginapi.SubscribeContext
 			json.Unmarshal(tc.Input, &ctx)
-			result, resultErr = plug.OnSubscribe(&ctx)
+			result, resultErr = d.subscribe(&ctx)
 		case "publish":
 			var ctx pluginapi.PublishContext
 			json.Unmarshal(tc.Input, &ctx)
-			plug.OnPublish(&ctx)
+			d.publish(&ctx)
 			result = true // OnPublish has no return value
 		case "disconnect":
 			var ctx pluginapi.DisconnectContext
 			json.Unmarshal(tc.Input, &ctx)
-			plug.OnDisconnect(&ctx)
+			d.disconnect(&ctx)
+			result = true
+		case "flush":
+			// 立即投递为 OnPublishBatch 缓冲的消息，不等待 BatchLinger
+			d.flushBatches("flush")
+			result = true
+		case "sleep":
+			// 等待托管定时任务执行，如 {"duration": "1.5s"}
+			var in struct {
+				Duration string `json:"duration"`
+			}
+			json.Unmarshal(tc.Input, &in)
+			dur, err := time.ParseDuration(in.Duration)
+			if err != nil {
+				fmt.Printf("SKIP (invalid duration: %v)\n", err)
+				continue
+			}
+			time.Sleep(dur)
 			result = true
+		case "reconfigure":
+			// 推送新配置，如 {"plugin": "auth_plugin", "config": {"users": {...}}}，命名实例写作 "auth_plugin@ops"
+			// 只加载一个插件时可省略 plugin；插件接受新配置时 allow=true
+			var in struct {
+				Plugin string          `json:"plugin"`
+				Config json.RawMessage `json:"config"`
+			}
+			json.Unmarshal(tc.Input, &in)
+			lp := d.find(in.Plugin)
+			if lp == nil {
+				fmt.Printf("SKIP (unknown plugin: %q)\n", in.Plugin)
+				continue
+			}
+			resultErr = d.reconfigure(lp, in.Config)
+			result = resultErr == nil
+		case "upgrade":
+			// 热升级到新版本，如 {"path": "./my_plugin_v2.so"}；升级成功时 allow=true
+			var in struct {
+				Path string `json:"path"`
+			}
+			json.Unmarshal(tc.Input, &in)
+			resultErr = d.upgrade(in.Path)
+			result = resultErr == nil
+		case "cluster":
+			// 模拟拓扑变化，如 {"action": "leader", "node": "node-2"}
+			var in struct {
+				Action string `json:"action"`
+				Node   string `json:"node"`
+			}
+			json.Unmarshal(tc.Input, &in)
+			resultErr = clusterAction(d.host.cluster, in.Action, in.Node)
+			result = resultErr == nil
 		default:
 			fmt.Printf("SKIP (unknown hook: %s)\n", tc.Hook)
 			continue
 		}
 
 		// 检查结果
-		ok := true
+		var problems []string
 		if tc.Expect.Allow != nil && result != *tc.Expect.Allow {
-			ok = false
+			problems = append(problems, fmt.Sprintf("want allow=%v", *tc.Expect.Allow))
 		}
 		if tc.Expect.Error != "" && (resultErr == nil || !strings.Contains(resultErr.Error(), tc.Expect.Error)) {
-			ok = false
+			problems = append(problems, fmt.Sprintf("want error containing %q", tc.Expect.Error))
+		}
+		for _, want := range tc.Expect.Banned {
+			subject, err := pluginapi.ParseSubject(want)
+			if err == nil {
+				if _, banned := d.host.security.Banned(subject); banned {
+					continue
+				}
+			}
+			problems = append(problems, "not banned: "+want)
+		}
+		for _, want := range matchLogs(d.host.logs.since(logMark), tc.Expect.Logs) {
+			problems = append(problems, fmt.Sprintf("missing log: level=%q contains=%q", want.Level, want.Contains))
 		}
 
-		if ok {
+		if len(problems) == 0 {
 			fmt.Println("PASS")
 			passed++
 		} else {
-			fmt.Printf("FAIL (got allow=%v, err=%v)\n", result, resultErr)
+			fmt.Printf("FAIL (got allow=%v, err=%v; %s)\n", result, resultErr, strings.Join(problems, "; "))
 			failed++
 		}
+		d.checkHealth(true)
 	}
 
 	fmt.Printf("\nResults: %d passed, %d failed\n", passed, failed)
+	if n := len(d.host.Published()); n > 0 {
+		fmt.Printf("Messages published by plugin: %d\n", n)
+	}
+	d.checkHealth(false)
 }
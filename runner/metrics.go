@@ -0,0 +1,43 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Metrics
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// printMetrics 以 Prometheus 文本格式打印插件指标
+func printMetrics(host *recordingHost) {
+	var buf bytes.Buffer
+	host.registry.WritePrometheus(&buf)
+	if buf.Len() == 0 {
+		return
+	}
+	fmt.Println("\nMetrics:")
+	fmt.Print(buf.String())
+}
+
+// serveMetrics 在后台通过 HTTP 导出插件指标
+func serveMetrics(host *recordingHost, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", host.registry.Handler())
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Printf("Metrics server stopped: %v\n", err)
+		}
+	}()
+	fmt.Printf("Serving metrics at http://%s/metrics\n", addr)
+}
+
+// waitSignal 等待 Ctrl+C
+func waitSignal() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	<-ch
+}
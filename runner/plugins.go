@@ -0,0 +1,333 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Plugin Lifecycle
+//
+// 加载、初始化、关闭多个插件，顺序与主程序一致
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// loadedPlugin 已加载的插件实例，加载流程见 loader 包
+type loadedPlugin struct {
+	*loader.Plugin
+	host *pluginHost
+
+	mu         sync.Mutex // 保证 Reconfigure 不与自身并发
+	fixedLevel bool       // 日志级别由 -log-level 指定，不随配置变化
+
+	batch     *batcher                         // 实现 BatchPublisher 时的发布缓冲
+	delivered atomic.Uint64                    // 已投递的 OnPublish 事件数
+	matched   atomic.Uint64                    // 通过 PublishFilter 主题与 QoS 条件的事件数（采样计数）
+	health    atomic.Pointer[pluginapi.Health] // 最近一次健康检查结果
+}
+
+// pluginSpecs 将 -plugin 与 -config 按位置配对
+// 配置文件名带 "@<实例名>" 时（如 webhook@billing.json）加载为命名实例，同一 .so 可以列出多次
+func pluginSpecs(paths, configPaths []string) ([]loader.Spec, error) {
+	if len(configPaths) > 0 && len(configPaths) != len(paths) {
+		fmt.Printf("Warning: %d config file(s) for %d plugin(s); configs are matched by position\n", len(configPaths), len(paths))
+	}
+	specs := make([]loader.Spec, len(paths))
+	for i, path := range paths {
+		specs[i].Path = path
+		if i >= len(configPaths) || configPaths[i] == "" {
+			continue
+		}
+		_, instance, err := pluginapi.ConfigFileInstance(configPaths[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", configPaths[i], err)
+		}
+		specs[i].ConfigPath, specs[i].Instance = configPaths[i], instance
+	}
+	return specs, nil
+}
+
+// loaderOptions 按命令行参数生成加载选项
+// 默认允许没有 .meta.json 的插件（直接 go build 的插件），-require-meta 时与主程序一样拒绝
+func loaderOptions() loader.Options {
+	return loader.Options{HostVersion: *hostVersion, AllowMissingMeta: !*requireMeta}
+}
+
+// loadPlugins 按主程序的流程加载全部插件实例，返回按执行顺序排列的结果
+// 与主程序一致，被拒绝的插件（打开失败、版本不兼容、重名、依赖不满足等）不影响其他插件
+func loadPlugins(specs []loader.Spec) ([]*loadedPlugin, error) {
+	loaded, err := loader.Load(specs, loaderOptions())
+	for _, e := range loader.Errors(err) {
+		fmt.Printf("Refused %v\n", e)
+	}
+	if len(loaded) == 0 {
+		return nil, errors.New("no plugin can be loaded")
+	}
+	plugins := make([]*loadedPlugin, len(loaded))
+	for i, p := range loaded {
+		plugins[i] = &loadedPlugin{Plugin: p}
+		notePlugin(p)
+	}
+	return plugins, nil
+}
+
+// notePlugin 提示调试器放宽、但主程序会检查的项目
+func notePlugin(p *loader.Plugin) {
+	if p.Sidecar == nil {
+		fmt.Printf("Note: %s has no %s; AXMQ refuses such plugins; build with tools/build, or check with -require-meta\n", p.Path, loader.MetaSuffix)
+	}
+	if *hostVersion == "" && (p.Meta.MinHostVersion != "" || p.Meta.MaxHostVersion != "") {
+		fmt.Printf("Note: %s declares supported AXMQ versions; pass -host-version to check them\n", p.Name())
+	}
+}
+
+// expandInstances 按插件顺序展开实例，见 loader.Expand
+func expandInstances(order []pluginapi.PluginMeta, plugins []*loadedPlugin) []*loadedPlugin {
+	return wrappers(loader.Expand(order, sources(plugins)), plugins)
+}
+
+// initOrder 返回依赖优先的初始化顺序，关闭时逆序，见 loader.InitOrder
+func initOrder(plugins []*loadedPlugin) []*loadedPlugin {
+	return wrappers(loader.InitOrder(sources(plugins)), plugins)
+}
+
+// sources 返回插件实例对应的 loader.Plugin
+func sources(plugins []*loadedPlugin) []*loader.Plugin {
+	out := make([]*loader.Plugin, len(plugins))
+	for i, lp := range plugins {
+		out[i] = lp.Plugin
+	}
+	return out
+}
+
+// wrappers 将 loader.Plugin 映射回 plugins 中对应的实例
+func wrappers(ps []*loader.Plugin, plugins []*loadedPlugin) []*loadedPlugin {
+	byPlugin := make(map[*loader.Plugin]*loadedPlugin, len(plugins))
+	for _, lp := range plugins {
+		byPlugin[lp.Plugin] = lp
+	}
+	out := make([]*loadedPlugin, len(ps))
+	for i, p := range ps {
+		out[i] = byPlugin[p]
+	}
+	return out
+}
+
+// printInfo 显示插件信息
+func (lp *loadedPlugin) printInfo() {
+	info := lp.Meta
+	fmt.Printf("Plugin loaded successfully:\n")
+	fmt.Printf("  Name:        %s\n", info.Name)
+	if lp.Instance != "" {
+		fmt.Printf("  Instance:    %s (%s)\n", lp.Instance, lp.ConfigPath)
+	}
+	fmt.Printf("  Version:     %s\n", info.Version)
+	fmt.Printf("  SDK Version: %s\n", info.SDKVersion)
+	fmt.Printf("  Go Version:  %s\n", info.GoVersion)
+	fmt.Printf("  Build Time:  %s\n", info.BuildTime)
+	if info.Description != "" {
+		fmt.Printf("  Description: %s\n", info.Description)
+	}
+	if info.Author != "" {
+		fmt.Printf("  Author:      %s\n", info.Author)
+	}
+	if info.License != "" {
+		fmt.Printf("  License:     %s\n", info.License)
+	}
+	if info.Homepage != "" {
+		fmt.Printf("  Homepage:    %s\n", info.Homepage)
+	}
+	if info.MinHostVersion != "" || info.MaxHostVersion != "" {
+		fmt.Printf("  AXMQ:        %s ~ %s\n", defaultIfEmpty(info.MinHostVersion, "any"), defaultIfEmpty(info.MaxHostVersion, "any"))
+	}
+	for _, dep := range info.Dependencies {
+		fmt.Printf("  Requires:    %s %s\n", dep.Name, defaultIfEmpty(dep.Version, "(any version)"))
+	}
+	fmt.Printf("  Hooks:       %s\n", info.GetHooks())
+	if len(info.ConfigSchema) > 0 {
+		fmt.Printf("  Config:      validated by schema before Init/Reconfigure\n")
+	}
+	for _, hook := range []pluginapi.HookMask{pluginapi.HookAuth, pluginapi.HookSubscribe, pluginapi.HookPublish, pluginapi.HookDisconnect} {
+		if _, ok := info.HookPolicies[hook]; ok {
+			p := info.GetHookPolicy(hook)
+			fmt.Printf("  %-13s timeout=%v on_failure=%s\n", hookName(hook)+":", p.Timeout, p.OnFailure)
+		}
+	}
+	if _, ok := lp.Impl.(pluginapi.BatchPublisher); ok {
+		fmt.Printf("  Batch:       size=%d linger=%v\n", info.GetBatchSize(), info.GetBatchLinger())
+	}
+	if f := info.PublishFilter; f != nil {
+		fmt.Printf("  Publish:     topics=%s min_qos=%d sample_rate=%v\n", strings.Join(f.Topics, ","), f.MinQoS, f.SampleRate)
+	}
+	if info.IsOrdered() {
+		fmt.Printf("  Priority:    %d\n", info.Priority)
+		fmt.Printf("  After:       %s\n", strings.Join(info.After, ", "))
+		fmt.Printf("  Final:       %v\n", info.Final)
+	}
+	fmt.Println()
+}
+
+// init 初始化插件，实现了 HostInitializer 的插件会收到 HostAPI
+// 实现了 ServiceWatcher、TopologyWatcher 的插件在初始化前登记，以便收到初始化期间发生的变化
+func (lp *loadedPlugin) init(host *recordingHost, config []byte, logLevel string) error {
+	config, err := lp.normalizeConfig(config)
+	if err != nil {
+		return err
+	}
+
+	level := new(slog.LevelVar)
+	if l, ok := pluginapi.LogLevelFromConfig(config); ok {
+		level.Set(l)
+	}
+	if logLevel != "" {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(logLevel)); err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
+		level.Set(l)
+		lp.fixedLevel = true
+	}
+
+	ph, err := host.forPlugin(lp.Meta.Name, lp.Instance, level)
+	if err != nil {
+		return err
+	}
+	lp.host = ph
+	lp.batch = newBatcher(lp)
+
+	if w, ok := lp.Impl.(pluginapi.ServiceWatcher); ok {
+		host.services.Watch(lp.Name(), w)
+	}
+	if w, ok := lp.Impl.(pluginapi.TopologyWatcher); ok {
+		host.cluster.Watch(lp.Name(), w)
+	}
+	if hi, ok := lp.Impl.(pluginapi.HostInitializer); ok {
+		return hi.InitWithHost(ph, config)
+	}
+	return lp.Impl.Init(config)
+}
+
+// normalizeConfig 将配置转换为 JSON 并按插件声明的 ConfigSchema 校验，不通过时不调用 Init / Reconfigure
+// 与主程序一致，插件总是收到 JSON 配置（YAML 已转换，空配置保持为空）
+func (lp *loadedPlugin) normalizeConfig(config []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(config)) > 0 {
+		var err error
+		if config, err = loader.ConfigJSON(config); err != nil {
+			return nil, err
+		}
+	}
+	if err := pluginapi.ValidateConfig(lp.Meta.ConfigSchema, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// reconfigure 推送新配置，插件拒绝时旧配置（含日志级别）继续生效
+func (lp *loadedPlugin) reconfigure(config []byte) error {
+	rc, ok := lp.Impl.(pluginapi.Reconfigurable)
+	if !ok {
+		return pluginapi.ErrNotReconfigurable
+	}
+
+	config, err := lp.normalizeConfig(config)
+	if err != nil {
+		return err
+	}
+
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	if err := rc.Reconfigure(config); err != nil {
+		return err
+	}
+	if l, ok := pluginapi.LogLevelFromConfig(config); ok && !lp.fixedLevel {
+		lp.host.level.Set(l)
+	}
+	return nil
+}
+
+// shutdown 按主程序的顺序关闭插件：投递缓冲的批次 -> 排空 -> 注销服务与回调 -> 取消托管任务 -> Close
+// 调用方保证不再投递事件（第一阶段：停止接收），返回超时未退出的任务
+func (lp *loadedPlugin) shutdown(host *recordingHost) []string {
+	if lp.batch != nil {
+		lp.batch.close("shutdown")
+	}
+	lp.drain()
+
+	host.cluster.Unwatch(lp.Name())
+	if removed := host.services.RemovePlugin(lp.Name()); len(removed) > 0 {
+		fmt.Printf("[host] %s: unregistered services: %s\n", lp.Name(), strings.Join(removed, ", "))
+	}
+
+	var leaked []string
+	if lp.host != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), pluginapi.TaskStopTimeout)
+		leaked = lp.host.scheduler.Shutdown(ctx)
+		cancel()
+	}
+
+	if err := lp.Impl.Close(); err != nil {
+		fmt.Printf("Plugin %s close error: %v\n", lp.Name(), err)
+	}
+	if len(leaked) > 0 {
+		fmt.Printf("WARNING: %s: %d task(s) still running after shutdown: %s\n",
+			lp.Name(), len(leaked), strings.Join(leaked, ", "))
+	}
+	return leaked
+}
+
+// drain 调用 Drainer 并核对是否在期限内完成；超过期限后不再等待
+func (lp *loadedPlugin) drain() {
+	dr, ok := lp.Impl.(pluginapi.Drainer)
+	if !ok {
+		return
+	}
+	timeout := lp.Meta.GetDrainTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- dr.Drain(ctx) }()
+	select {
+	case err := <-done:
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if err != nil {
+			fmt.Printf("WARNING: %s: drain failed after %v (deadline %v): %v\n", lp.Name(), elapsed, timeout, err)
+			return
+		}
+		fmt.Printf("[host] %s: drained in %v (deadline %v)\n", lp.Name(), elapsed, timeout)
+	case <-ctx.Done():
+		fmt.Printf("WARNING: %s: drain did not finish within %v; closing anyway, unflushed data is lost\n", lp.Name(), timeout)
+	}
+}
+
+// shutdownPlugins 按初始化的逆序（依赖它的插件先关闭）关闭已初始化的插件，并报告残留的 goroutine
+func shutdownPlugins(plugins []*loadedPlugin, host *recordingHost, baseGoroutines int) {
+	plugins = initOrder(plugins)
+	leaked := 0
+	for i := len(plugins) - 1; i >= 0; i-- {
+		leaked += len(plugins[i].shutdown(host))
+	}
+
+	// 插件自行启动的 goroutine 无法被跟踪，只能通过数量变化提示
+	time.Sleep(100 * time.Millisecond)
+	if n := runtime.NumGoroutine() - baseGoroutines; n > leaked {
+		fmt.Printf("WARNING: %d goroutine(s) started by plugins are still running; use HostAPI.Scheduler() for background work\n", n-leaked)
+	}
+}
+
+func defaultIfEmpty(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
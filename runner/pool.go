@@ -0,0 +1,56 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Context Pool
+//
+// 与主程序一致，OnPublish 的上下文与载荷缓冲区来自对象池，由声明了 SharedPublish 的插件共享且只读
+// 调用返回后回收并覆盖载荷，违反生命周期约定（返回后继续引用 Payload）的插件在调试时即可暴露问题
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// poisonByte 回收时写入载荷缓冲区的字节
+const poisonByte = 0xDB
+
+// pooledPublish 池化的发布上下文
+type pooledPublish struct {
+	ctx pluginapi.PublishContext
+	buf []byte
+}
+
+var publishPool = sync.Pool{New: func() any { return new(pooledPublish) }}
+
+// acquirePublish 从对象池取出上下文并填充消息
+func acquirePublish(msg *pluginapi.PublishContext) *pooledPublish {
+	p := publishPool.Get().(*pooledPublish)
+	p.buf = append(p.buf[:0], msg.Payload...)
+	p.ctx = *msg
+	p.ctx.Payload = p.buf
+	return p
+}
+
+// release 覆盖载荷后放回对象池
+func (p *pooledPublish) release() {
+	for i := range p.buf {
+		p.buf[i] = poisonByte
+	}
+	p.ctx = pluginapi.PublishContext{}
+	publishPool.Put(p)
+}
+
+// checkShared 核对插件没有修改共享的上下文，被修改时恢复原值，避免影响后续插件
+func checkShared(lp *loadedPlugin, shared, msg *pluginapi.PublishContext) {
+	payload := shared.Payload
+	if shared.ClientID == msg.ClientID && shared.Username == msg.Username && shared.Topic == msg.Topic &&
+		shared.QoS == msg.QoS && shared.Retain == msg.Retain && bytes.Equal(payload, msg.Payload) {
+		return
+	}
+	fmt.Printf("WARNING: %s modified the shared PublishContext; contexts are read-only, use Clone() to get a private copy\n", lp.Name())
+	*shared = *msg
+	shared.Payload = append(payload[:0], msg.Payload...)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Security Manager
+//
+// 本地版 SecurityManager，行为与主程序一致：累计达阈值自动封禁并踢下线
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// autoBanDuration 达到阈值自动封禁的时长
+const autoBanDuration = 10 * time.Minute
+
+// localSecurity 本地版 SecurityManager 实现
+type localSecurity struct {
+	mu      sync.Mutex
+	scores  map[pluginapi.Subject]int
+	bans    map[pluginapi.Subject]pluginapi.Ban
+	clients *memClients
+}
+
+var _ pluginapi.SecurityManager = (*localSecurity)(nil)
+
+func newLocalSecurity(clients *memClients) *localSecurity {
+	return &localSecurity{
+		scores:  make(map[pluginapi.Subject]int),
+		bans:    make(map[pluginapi.Subject]pluginapi.Ban),
+		clients: clients,
+	}
+}
+
+// ReportThreat 累加威胁分，达到阈值自动封禁
+// 累计与封禁判断在同一把锁内完成，并发上报只会触发一次自动封禁
+func (s *localSecurity) ReportThreat(subject pluginapi.Subject, score int, reason string) (int, error) {
+	if err := subject.Validate(); err != nil {
+		return 0, err
+	}
+	if score <= 0 {
+		return 0, fmt.Errorf("%w: %d", pluginapi.ErrInvalidThreatScore, score)
+	}
+
+	s.mu.Lock()
+	s.scores[subject] += score
+	total := s.scores[subject]
+	var ban pluginapi.Ban
+	autoBan := false
+	if total >= pluginapi.DefaultThreatThreshold {
+		if _, banned := s.activeBan(subject); !banned {
+			ban = s.addBan(subject, autoBanDuration, fmt.Sprintf("threat score %d reached threshold", total))
+			autoBan = true
+		}
+	}
+	s.mu.Unlock()
+
+	fmt.Printf("[host] threat: %s +%d = %d (%s)\n", subject, score, total, reason)
+	if autoBan {
+		s.enforce(ban, autoBanDuration)
+	}
+	return total, nil
+}
+
+// ThreatScore 查询累计威胁分
+func (s *localSecurity) ThreatScore(subject pluginapi.Subject) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scores[subject]
+}
+
+// Ban 封禁对象并断开匹配的在线客户端
+func (s *localSecurity) Ban(subject pluginapi.Subject, duration time.Duration, reason string) error {
+	if err := subject.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	ban := s.addBan(subject, duration, reason)
+	s.mu.Unlock()
+
+	s.enforce(ban, duration)
+	return nil
+}
+
+// addBan 记录封禁（调用方持有锁）
+func (s *localSecurity) addBan(subject pluginapi.Subject, duration time.Duration, reason string) pluginapi.Ban {
+	ban := pluginapi.Ban{Subject: subject, Reason: reason, CreatedAt: time.Now()}
+	if duration > 0 {
+		ban.ExpiresAt = ban.CreatedAt.Add(duration)
+	}
+	s.bans[subject] = ban
+	return ban
+}
+
+// enforce 打印封禁并断开匹配的在线客户端（不持有锁，断开会触发 OnDisconnect 钩子）
+func (s *localSecurity) enforce(ban pluginapi.Ban, duration time.Duration) {
+	if ban.Permanent() {
+		fmt.Printf("[host] ban: %s permanently (%s)\n", ban.Subject, ban.Reason)
+	} else {
+		fmt.Printf("[host] ban: %s for %v (%s)\n", ban.Subject, duration, ban.Reason)
+	}
+
+	for _, clientID := range s.clients.matching(ban.Subject) {
+		s.clients.Disconnect(clientID, pluginapi.ReasonNotAuthorized)
+	}
+}
+
+// Unban 解除封禁并清零威胁分
+func (s *localSecurity) Unban(subject pluginapi.Subject) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.activeBan(subject); !ok {
+		return fmt.Errorf("%w: %s", pluginapi.ErrBanNotFound, subject)
+	}
+	delete(s.bans, subject)
+	delete(s.scores, subject)
+	fmt.Printf("[host] unban: %s\n", subject)
+	return nil
+}
+
+// Banned 查询是否封禁中
+func (s *localSecurity) Banned(subject pluginapi.Subject) (pluginapi.Ban, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activeBan(subject)
+}
+
+// Bans 列出生效中的封禁（按对象排序）
+func (s *localSecurity) Bans() []pluginapi.Ban {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bans := make([]pluginapi.Ban, 0, len(s.bans))
+	for subject := range s.bans {
+		if ban, ok := s.activeBan(subject); ok {
+			bans = append(bans, ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Subject.String() < bans[j].Subject.String() })
+	return bans
+}
+
+// activeBan 返回未过期的封禁，过期记录顺带清理（调用方持有锁）
+func (s *localSecurity) activeBan(subject pluginapi.Subject) (pluginapi.Ban, bool) {
+	ban, ok := s.bans[subject]
+	if !ok {
+		return pluginapi.Ban{}, false
+	}
+	if !ban.Permanent() && time.Now().After(ban.ExpiresAt) {
+		delete(s.bans, subject)
+		delete(s.scores, subject)
+		return pluginapi.Ban{}, false
+	}
+	return ban, true
+}
+
+// refused 检查连接是否命中封禁
+func (s *localSecurity) refused(clientID, username, ip string) (pluginapi.Ban, bool) {
+	candidates := []pluginapi.Subject{
+		pluginapi.IPSubject(ip),
+		pluginapi.UsernameSubject(username),
+		pluginapi.ClientIDSubject(clientID),
+	}
+	for _, subject := range candidates {
+		if subject.Value == "" {
+			continue
+		}
+		if ban, ok := s.Banned(subject); ok {
+			return ban, true
+		}
+	}
+	return pluginapi.Ban{}, false
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Store
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/kvstore"
+)
+
+// openStore 打开文件版存储，每个插件使用以插件名命名的命名空间
+// dir 为空时使用临时目录，退出时删除；指定目录则跨次运行保留数据，可模拟热加载与重启
+func openStore(dir string) (*kvstore.FileStore, func(), error) {
+	cleanup := func() {}
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "axmq-runner-store-")
+		if err != nil {
+			return nil, nil, err
+		}
+		dir = tmp
+		cleanup = func() { os.RemoveAll(tmp) }
+	}
+
+	fs, err := kvstore.Open(dir)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	fmt.Printf("Store: %s\n", fs.Dir())
+	return fs, cleanup, nil
+}
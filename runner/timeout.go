@@ -0,0 +1,74 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Hook Timeout
+//
+// 按 PluginMeta.GetHookPolicy 为每次钩子调用计时，超时或 panic 时按失败策略处理
+// 与主程序一致，超时的调用不会被中断，插件应自行遵守超时
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// errHookTimedOut 钩子超时，调用仍在后台执行，上下文不能回收
+var errHookTimedOut = errors.New("timed out")
+
+// hookResult 钩子调用结果
+type hookResult[T any] struct {
+	value    T
+	panicked any
+}
+
+// callHook 在独立的 goroutine 中调用钩子，超时或 panic 时返回错误
+func callHook[T any](timeout time.Duration, fn func() T) (T, error) {
+	done := make(chan hookResult[T], 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- hookResult[T]{panicked: v}
+			}
+		}()
+		done <- hookResult[T]{value: fn()}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var zero T
+	select {
+	case r := <-done:
+		if r.panicked != nil {
+			return zero, fmt.Errorf("panic: %v", r.panicked)
+		}
+		return r.value, nil
+	case <-timer.C:
+		return zero, fmt.Errorf("%w after %v", errHookTimedOut, timeout)
+	}
+}
+
+// hookName 返回钩子方法名，用于日志
+func hookName(hook pluginapi.HookMask) string {
+	switch hook {
+	case pluginapi.HookAuth:
+		return "OnAuth"
+	case pluginapi.HookSubscribe:
+		return "OnSubscribe"
+	case pluginapi.HookPublish:
+		return "OnPublish"
+	case pluginapi.HookDisconnect:
+		return "OnDisconnect"
+	default:
+		return hook.String()
+	}
+}
+
+// failureAction 描述失败策略的处理结果
+func failureAction(policy pluginapi.FailurePolicy) string {
+	if policy == pluginapi.FailOpen {
+		return "fail-open, plugin skipped"
+	}
+	return "fail-closed, denied"
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Hot Upgrade
+//
+// 模拟主程序覆盖 .so 时的热升级流程，见 pluginapi/snapshot.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// upgrade 将同名插件的所有实例替换为 path 中的新版本，期间的事件等待升级完成后投递给新实例
+// 返回的 error 表示升级被放弃（旧版本继续服务）或部分实例初始化失败（这些实例已下线）
+func (d *dispatcher) upgrade(path string) error {
+	// 1. 按加载流程打开新版本并校验（含主程序版本），不影响旧版本服务
+	p, err := loader.Open(loader.Spec{Path: path}, loaderOptions())
+	if err != nil {
+		return err
+	}
+	info := p.Meta
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	start := time.Now()
+
+	// 每个旧实例对应一个新实例，各自调用一次 NewPlugin()
+	candidates := append([]*loadedPlugin(nil), d.plugins...)
+	var olds, nexts []*loadedPlugin
+	for i, lp := range candidates {
+		if lp.Meta.Name != info.Name {
+			continue
+		}
+		spec := loader.Spec{Path: path, ConfigPath: lp.ConfigPath, Instance: lp.Instance}
+		if len(nexts) > 0 {
+			if p, err = loader.Open(spec, loaderOptions()); err != nil {
+				return err
+			}
+		}
+		p.Spec = spec
+		next := &loadedPlugin{Plugin: p}
+		olds, nexts = append(olds, lp), append(nexts, next)
+		candidates[i] = next
+	}
+	if len(olds) == 0 {
+		return fmt.Errorf("%w: no loaded plugin named %s", pluginapi.ErrUpgradeNameMismatch, info.Name)
+	}
+	oldVersion := olds[0].Meta.Version
+
+	// 新版本的依赖必须已加载，依赖它的插件也必须接受新版本
+	if _, err := pluginapi.ResolveDependencies(metasOf(candidates)); err != nil {
+		return fmt.Errorf("keeping %s %s: %w", info.Name, oldVersion, err)
+	}
+	sorted, err := pluginapi.SortPlugins(metasOf(candidates))
+	if err != nil {
+		return err
+	}
+
+	// 2~3. 事件已暂停（持有写锁），投递旧实例缓冲的批次后取快照；任一实例快照失败则全部保留旧版本
+	snaps := make([]*pluginapi.Snapshot, len(olds))
+	for i, old := range olds {
+		if old.batch != nil {
+			old.batch.flush("upgrade")
+		}
+		if s, ok := old.Impl.(pluginapi.Snapshotter); ok {
+			sn, err := s.Snapshot()
+			if err != nil {
+				return fmt.Errorf("snapshot of %s failed, keeping %s %s: %w", old.Name(), info.Name, oldVersion, err)
+			}
+			sn.Plugin, sn.Version = old.Meta.Name, old.Meta.Version
+			snaps[i] = &sn
+		}
+	}
+
+	// 4. 关闭旧实例
+	for _, old := range olds {
+		old.shutdown(d.host)
+	}
+
+	// 5. 初始化新实例并恢复各自的状态，初始化失败的实例下线
+	var errs []error
+	offline := make(map[*loadedPlugin]bool)
+	for i, next := range nexts {
+		var config []byte
+		if next.ConfigPath != "" {
+			if config, err = os.ReadFile(next.ConfigPath); err != nil {
+				fmt.Printf("Warning: failed to read config file: %v\n", err)
+			}
+		}
+		if err := next.init(d.host, config, d.logLevel); err != nil {
+			next.shutdown(d.host)
+			offline[next] = true
+			errs = append(errs, fmt.Errorf("%w: %s %s is now offline: %v", pluginapi.ErrPluginInitFailed, next.Name(), info.Version, err))
+			continue
+		}
+		if next.batch != nil {
+			next.batch.quiet.Store(d.benchmark)
+		}
+		restored := "no state"
+		if snap := snaps[i]; snap != nil {
+			if r, ok := next.Impl.(pluginapi.Snapshotter); !ok {
+				restored = "state dropped (new version does not implement Snapshotter)"
+			} else if err := r.Restore(*snap); err != nil {
+				restored = fmt.Sprintf("state not restored: %v", err)
+			} else {
+				restored = fmt.Sprintf("restored %d bytes (format %d)", len(snap.Data), snap.Format)
+			}
+		}
+		fmt.Printf("[host] upgraded %s %s -> %s in %v: %s\n",
+			next.Name(), oldVersion, info.Version, time.Since(start).Round(time.Microsecond), restored)
+	}
+
+	// 6. 按新的元信息排序，恢复投递
+	online := candidates[:0]
+	for _, lp := range candidates {
+		if !offline[lp] {
+			online = append(online, lp)
+		}
+	}
+	d.plugins = expandInstances(sorted, online)
+	d.sequential = pluginapi.Sequential(sorted)
+	return errors.Join(errs...)
+}
+
+func handleUpgrade(d *dispatcher, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Usage: upgrade <path/to/new.so>")
+		return
+	}
+	if err := d.upgrade(args[0]); err != nil {
+		fmt.Printf("Upgrade failed: %v\n", err)
+	}
+}
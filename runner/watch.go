@@ -0,0 +1,75 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Local Debug Runner Config Watcher
+//
+// 监视配置文件，内容变化时通过 Reconfigurable 推送给已加载的插件，与主程序行为一致
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// watchInterval 配置文件轮询间隔
+const watchInterval = 500 * time.Millisecond
+
+// watchConfigs 在后台轮询插件的配置文件，返回停止函数
+// configs 为初始化时使用的配置内容，以实例的完整名称为键
+func watchConfigs(d *dispatcher, configs map[string][]byte) (stop func()) {
+	last := make(map[string][]byte) // 实例的完整名称 -> 最近一次读到的配置
+	for _, lp := range d.current() {
+		if lp.ConfigPath != "" {
+			last[lp.Name()] = configs[lp.Name()]
+			fmt.Printf("Watching %s for %s\n", lp.ConfigPath, lp.Name())
+		}
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			// 每次重新获取插件列表，热升级后推送给新实例
+			for _, lp := range d.current() {
+				if lp.ConfigPath == "" {
+					continue
+				}
+				data, err := os.ReadFile(lp.ConfigPath)
+				if err != nil || bytes.Equal(data, last[lp.Name()]) {
+					// 编辑器保存期间文件可能暂时不存在，下次轮询再读
+					continue
+				}
+				last[lp.Name()] = data
+				reportReconfigure(lp, d.reconfigure(lp, data))
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// reportReconfigure 打印配置更新结果
+func reportReconfigure(lp *loadedPlugin, err error) {
+	switch {
+	case err == nil:
+		fmt.Printf("[host] %s: config reloaded\n", lp.Name())
+	case errors.Is(err, pluginapi.ErrNotReconfigurable):
+		fmt.Printf("[host] %s: config changed, but the plugin does not implement Reconfigurable; reload the .so to apply it\n", lp.Name())
+	default:
+		fmt.Printf("[host] %s: config rejected, keeping previous config: %v\n", lp.Name(), err)
+	}
+}
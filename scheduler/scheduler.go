@@ -0,0 +1,167 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Task Scheduler
+//
+// pluginapi.Scheduler 的参考实现，每个插件实例使用一个 Scheduler
+//
+// 使用方法：
+//   s := scheduler.New(func(name string, v any) { log.Printf("task %s panic: %v", name, v) })
+//   // 传给插件 ...
+//   leaked := s.Shutdown(ctx)   // 插件 Close 之前调用
+
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// PanicHandler 任务回调 panic 时调用
+type PanicHandler func(task string, v any)
+
+// Scheduler 托管任务调度器
+type Scheduler struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	onPanic PanicHandler
+
+	mu     sync.Mutex
+	tasks  map[*task]struct{}
+	closed bool
+}
+
+var _ pluginapi.Scheduler = (*Scheduler)(nil)
+
+// New 创建调度器，onPanic 可为 nil
+func New(onPanic PanicHandler) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		ctx:     ctx,
+		cancel:  cancel,
+		onPanic: onPanic,
+		tasks:   make(map[*task]struct{}),
+	}
+}
+
+// task 托管任务
+type task struct {
+	name   string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (t *task) Name() string          { return t.name }
+func (t *task) Cancel()               { t.cancel() }
+func (t *task) Done() <-chan struct{} { return t.done }
+
+// start 注册任务并在后台运行 run
+func (s *Scheduler) start(name string, run func(ctx context.Context, call func(func(context.Context)))) (pluginapi.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, pluginapi.ErrSchedulerClosed
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	t := &task{name: name, cancel: cancel, done: make(chan struct{})}
+	s.tasks[t] = struct{}{}
+
+	go func() {
+		defer func() {
+			cancel()
+			s.mu.Lock()
+			delete(s.tasks, t)
+			s.mu.Unlock()
+			close(t.done)
+		}()
+		run(ctx, func(fn func(context.Context)) { s.call(ctx, name, fn) })
+	}()
+	return t, nil
+}
+
+// call 执行回调并捕获 panic
+func (s *Scheduler) call(ctx context.Context, name string, fn func(context.Context)) {
+	defer func() {
+		if v := recover(); v != nil && s.onPanic != nil {
+			s.onPanic(name, v)
+		}
+	}()
+	fn(ctx)
+}
+
+// Every 周期任务
+func (s *Scheduler) Every(name string, interval time.Duration, fn func(ctx context.Context)) (pluginapi.Task, error) {
+	if interval <= 0 {
+		return nil, pluginapi.ErrInvalidInterval
+	}
+	return s.start(name, func(ctx context.Context, call func(func(context.Context))) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// 回调同步执行，期间错过的 tick 被 Ticker 丢弃，不会并发执行
+				call(fn)
+			}
+		}
+	})
+}
+
+// After 一次性定时任务
+func (s *Scheduler) After(name string, delay time.Duration, fn func(ctx context.Context)) (pluginapi.Task, error) {
+	return s.start(name, func(ctx context.Context, call func(func(context.Context))) {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			call(fn)
+		}
+	})
+}
+
+// Go 后台任务
+func (s *Scheduler) Go(name string, fn func(ctx context.Context)) (pluginapi.Task, error) {
+	return s.start(name, func(ctx context.Context, call func(func(context.Context))) {
+		call(fn)
+	})
+}
+
+// Running 返回仍在运行的任务名称（排序）
+func (s *Scheduler) Running() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.tasks))
+	for t := range s.tasks {
+		names = append(names, t.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Shutdown 取消所有任务并等待其退出，返回在 ctx 到期前仍未退出的任务名称
+// 调用后不再接受新任务
+func (s *Scheduler) Shutdown(ctx context.Context) []string {
+	s.mu.Lock()
+	s.closed = true
+	pending := make([]*task, 0, len(s.tasks))
+	for t := range s.tasks {
+		pending = append(pending, t)
+	}
+	s.mu.Unlock()
+
+	s.cancel()
+	for _, t := range pending {
+		select {
+		case <-t.done:
+		case <-ctx.Done():
+			return s.Running()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Service Registry
+//
+// pluginapi.ServiceRegistry 的参考实现，所有插件共享一个 Registry
+//
+// 使用方法：
+//   reg := services.New()
+//   reg.Watch("acl", aclPlugin)          // 插件实现了 pluginapi.ServiceWatcher 时
+//   sr := reg.ForPlugin("acl")           // 传给插件的 pluginapi.ServiceRegistry
+//   reg.RemovePlugin("user_directory")   // 卸载插件前注销其全部服务
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+type entry struct {
+	provider string
+	version  string
+	impl     any
+}
+
+// Registry 服务注册表
+type Registry struct {
+	mu       sync.RWMutex
+	entries  map[string]entry
+	watchers map[string]pluginapi.ServiceWatcher
+}
+
+// New 创建注册表
+func New() *Registry {
+	return &Registry{
+		entries:  make(map[string]entry),
+		watchers: make(map[string]pluginapi.ServiceWatcher),
+	}
+}
+
+// ForPlugin 返回以 plugin 身份操作的 ServiceRegistry
+func (r *Registry) ForPlugin(plugin string) pluginapi.ServiceRegistry {
+	return &pluginView{reg: r, plugin: plugin}
+}
+
+// Watch 登记插件的服务变化回调
+func (r *Registry) Watch(plugin string, w pluginapi.ServiceWatcher) {
+	r.mu.Lock()
+	r.watchers[plugin] = w
+	r.mu.Unlock()
+}
+
+// RemovePlugin 注销插件的全部服务并移除其回调，返回被注销的服务名称
+func (r *Registry) RemovePlugin(plugin string) []string {
+	r.mu.Lock()
+	delete(r.watchers, plugin)
+	var events []pluginapi.ServiceEvent
+	for name, e := range r.entries {
+		if e.provider == plugin {
+			delete(r.entries, name)
+			events = append(events, pluginapi.ServiceEvent{
+				Type: pluginapi.ServiceUnregistered, Name: name, Version: e.version, Provider: plugin,
+			})
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	names := make([]string, len(events))
+	for i, ev := range events {
+		names[i] = ev.Name
+		r.notify(ev)
+	}
+	return names
+}
+
+// Services 列出已注册的服务（按名称排序）
+func (r *Registry) Services() []pluginapi.ServiceEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]pluginapi.ServiceEvent, 0, len(r.entries))
+	for name, e := range r.entries {
+		list = append(list, pluginapi.ServiceEvent{
+			Type: pluginapi.ServiceRegistered, Name: name, Version: e.version, Provider: e.provider,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// notify 在锁外向除提供方以外的插件投递事件
+func (r *Registry) notify(ev pluginapi.ServiceEvent) {
+	r.mu.RLock()
+	plugins := make([]string, 0, len(r.watchers))
+	for plugin := range r.watchers {
+		if plugin != ev.Provider {
+			plugins = append(plugins, plugin)
+		}
+	}
+	sort.Strings(plugins)
+	watchers := make([]pluginapi.ServiceWatcher, len(plugins))
+	for i, plugin := range plugins {
+		watchers[i] = r.watchers[plugin]
+	}
+	r.mu.RUnlock()
+
+	for _, w := range watchers {
+		w.OnServiceChange(ev)
+	}
+}
+
+// pluginView 单个插件的注册表视图
+type pluginView struct {
+	reg    *Registry
+	plugin string
+}
+
+func (v *pluginView) Register(name, version string, impl any) error {
+	if name == "" || impl == nil {
+		return fmt.Errorf("%w: empty name or nil implementation", pluginapi.ErrInvalidService)
+	}
+	if err := pluginapi.CheckServiceVersion(version, ""); err != nil {
+		return err
+	}
+
+	v.reg.mu.Lock()
+	if e, ok := v.reg.entries[name]; ok && e.provider != v.plugin {
+		v.reg.mu.Unlock()
+		return fmt.Errorf("%w: %s (provided by %s)", pluginapi.ErrServiceAlreadyRegistered, name, e.provider)
+	}
+	v.reg.entries[name] = entry{provider: v.plugin, version: version, impl: impl}
+	v.reg.mu.Unlock()
+
+	v.reg.notify(pluginapi.ServiceEvent{
+		Type: pluginapi.ServiceRegistered, Name: name, Version: version, Provider: v.plugin,
+	})
+	return nil
+}
+
+func (v *pluginView) Unregister(name string) error {
+	v.reg.mu.Lock()
+	e, ok := v.reg.entries[name]
+	if !ok || e.provider != v.plugin {
+		v.reg.mu.Unlock()
+		return fmt.Errorf("%w: %s", pluginapi.ErrServiceNotFound, name)
+	}
+	delete(v.reg.entries, name)
+	v.reg.mu.Unlock()
+
+	v.reg.notify(pluginapi.ServiceEvent{
+		Type: pluginapi.ServiceUnregistered, Name: name, Version: e.version, Provider: v.plugin,
+	})
+	return nil
+}
+
+func (v *pluginView) Lookup(name, minVersion string) (any, error) {
+	v.reg.mu.RLock()
+	e, ok := v.reg.entries[name]
+	v.reg.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", pluginapi.ErrServiceNotFound, name)
+	}
+	if err := pluginapi.CheckServiceVersion(e.version, minVersion); err != nil {
+		return nil, fmt.Errorf("service %s: %w", name, err)
+	}
+	return e.impl, nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 AXMQ Authors
+// AXMQ Plugin SDK - Build Tool Hook Detection
+//
+// 静态分析插件源码，找出插件类型自身实现（而非继承自 BasePlugin）的钩子
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	pathpkg "path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+)
+
+// hookMethods 钩子方法名与掩码的对应关系（OnPublishBatch 见 BatchPublisher）
+var hookMethods = map[string]pluginapi.HookMask{
+	"OnAuth":         pluginapi.HookAuth,
+	"OnSubscribe":    pluginapi.HookSubscribe,
+	"OnPublish":      pluginapi.HookPublish,
+	"OnPublishBatch": pluginapi.HookPublish,
+	"OnDisconnect":   pluginapi.HookDisconnect,
+}
+
+// pluginapiPath pluginapi 包的导入路径
+const pluginapiPath = "github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
+
+// sourcePackage 插件包中与钩子检测有关的信息
+type sourcePackage struct {
+	methods  map[string]map[string]bool // 类型名 -> 方法名
+	embedded map[string][]string        // 类型名 -> 嵌入的同包类型
+	external map[string][]string        // 类型名 -> 嵌入的其他包类型（pluginapi.BasePlugin 除外）或接口，方法无法静态分析
+	newType  string                     // NewPlugin 返回的类型
+}
+
+// detectHooks 返回插件类型实现的钩子
+// 只统计插件类型及其嵌入的同包类型上声明的方法，嵌入 pluginapi.BasePlugin 获得的默认实现不计入；
+// 嵌入了其他包的类型或接口时无法确定钩子集合，返回错误，调用方应使用 0（全部钩子）
+func detectHooks(dir string) (pluginapi.HookMask, string, error) {
+	pkg, err := parseSource(dir)
+	if err != nil {
+		return 0, "", err
+	}
+	typ := pkg.newType
+	if typ == "" {
+		// 无法从 NewPlugin 推断时，使用唯一声明了 Info 方法的类型
+		var candidates []string
+		for name, methods := range pkg.methods {
+			if methods["Info"] {
+				candidates = append(candidates, name)
+			}
+		}
+		if len(candidates) != 1 {
+			return 0, "", fmt.Errorf("cannot determine the plugin type returned by NewPlugin (candidates: %v)", candidates)
+		}
+		typ = candidates[0]
+	}
+
+	var mask pluginapi.HookMask
+	var external []string
+	seen := make(map[string]bool)
+	var walk func(name string)
+	walk = func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		for method := range pkg.methods[name] {
+			mask |= hookMethods[method]
+		}
+		external = append(external, pkg.external[name]...)
+		for _, emb := range pkg.embedded[name] {
+			walk(emb)
+		}
+	}
+	walk(typ)
+	if len(external) > 0 {
+		return 0, typ, fmt.Errorf("%s embeds %v, whose hooks cannot be detected", typ, external)
+	}
+	return mask, typ, nil
+}
+
+// embeddedField 结构体中的嵌入字段
+type embeddedField struct {
+	owner   string            // 所属类型
+	expr    ast.Expr          // 字段类型
+	imports map[string]string // 所在文件的导入：包名 -> 路径，点导入的包名为 "."
+}
+
+// parseSource 按目标平台的构建约束解析目录中的 Go 文件（不含测试）
+func parseSource(dir string) (*sourcePackage, error) {
+	ctx := build.Default
+	ctx.GOOS = defaultIfEmpty(*targetOS, runtime.GOOS)
+	ctx.GOARCH = defaultIfEmpty(*targetArch, runtime.GOARCH)
+	ctx.CgoEnabled = true
+	bp, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	if bp.Name != "main" {
+		return nil, fmt.Errorf("plugin package must be main, got %s", bp.Name)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range append(bp.GoFiles, bp.CgoFiles...) {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	pkg := &sourcePackage{
+		methods:  make(map[string]map[string]bool),
+		embedded: make(map[string][]string),
+		external: make(map[string][]string),
+	}
+	declared := make(map[string]bool)   // 同包声明的类型
+	interfaces := make(map[string]bool) // 同包声明的接口类型，嵌入后的方法同样无法静态分析
+	var fields []embeddedField
+	for _, file := range files {
+		imports := fileImports(file)
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					if d.Name.Name == "NewPlugin" && d.Body != nil {
+						pkg.newType = returnedType(d.Body)
+					}
+					continue
+				}
+				if len(d.Recv.List) == 0 {
+					continue
+				}
+				recv := typeName(d.Recv.List[0].Type)
+				if pkg.methods[recv] == nil {
+					pkg.methods[recv] = make(map[string]bool)
+				}
+				pkg.methods[recv][d.Name.Name] = true
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					declared[ts.Name.Name] = true
+					if _, ok := ts.Type.(*ast.InterfaceType); ok {
+						interfaces[ts.Name.Name] = true
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						if len(field.Names) == 0 {
+							fields = append(fields, embeddedField{owner: ts.Name.Name, expr: field.Type, imports: imports})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// 所有类型声明收集完后再区分同包类型与其他包的类型
+	for _, f := range fields {
+		if name := typeName(f.expr); name != "" && declared[name] && !interfaces[name] {
+			pkg.embedded[f.owner] = append(pkg.embedded[f.owner], name)
+			continue
+		}
+		if isBasePlugin(f.expr, f.imports) {
+			continue
+		}
+		pkg.external[f.owner] = append(pkg.external[f.owner], types.ExprString(f.expr))
+	}
+	return pkg, nil
+}
+
+// fileImports 返回文件的导入：包名 -> 路径（未写别名时取路径最后一段）
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string, len(file.Imports))
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := pathpkg.Base(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// isBasePlugin 嵌入字段是否为 pluginapi.BasePlugin（含别名导入与点导入）
+func isBasePlugin(expr ast.Expr, imports map[string]string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name == "BasePlugin" && imports["."] == pluginapiPath
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		return ok && t.Sel.Name == "BasePlugin" && imports[pkg.Name] == pluginapiPath
+	}
+	return false
+}
+
+// returnedType 从 NewPlugin 的 return 语句中找出 &T{...} 或 T{...} 的类型名
+func returnedType(body *ast.BlockStmt) string {
+	var found string
+	ast.Inspect(body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 || found != "" {
+			return found == ""
+		}
+		expr := ret.Results[0]
+		if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			expr = u.X
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok {
+			found = typeName(lit.Type)
+		}
+		return false
+	})
+	return found
+}
+
+// typeName 返回 T、*T 或 T[...] 中同包类型 T 的名称，其他表达式返回空
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	}
+	return ""
+}
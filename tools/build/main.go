@@ -2,14 +2,17 @@
 // AXMQ Plugin SDK - Build Tool
 //
 // 构建插件并生成元数据清单，确保与主程序的版本一致性
+// 清单中的 hooks 由源码分析得出，主程序据此跳过插件未实现的钩子
 //
 // 使用方法：
-//   go run tools/build/main.go -dir ./my_plugin -output ./my_plugin.so
-//   go run tools/build/main.go -dir ./my_plugin -output ./my_plugin.so -goos linux -goarch amd64
+//   go run ./tools/build -dir ./my_plugin -output ./my_plugin.so
+//   go run ./tools/build -dir ./my_plugin -output ./my_plugin.so -goos linux -goarch amd64
+//   go run ./tools/build -dir ./my_plugin -output ./my_plugin.so -config ./my_plugin.json
 
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -20,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/AXMQ-NET/axmq-plugin-sdk/loader"
 	"github.com/AXMQ-NET/axmq-plugin-sdk/pluginapi"
 )
 
@@ -29,16 +33,9 @@ var (
 	verbose    = flag.Bool("v", false, "Verbose output")
 	targetOS   = flag.String("goos", "", "Target GOOS (empty = host)")
 	targetArch = flag.String("goarch", "", "Target GOARCH (empty = host)")
+	sampleCfg  = flag.String("config", "", "Sample config to validate against "+pluginapi.ConfigSchemaFile+" (optional, JSON or YAML)")
 )
 
-// BuildMeta 构建元数据（写入到 .meta.json）
-type BuildMeta struct {
-	pluginapi.PluginMeta
-	BuildHost string `json:"build_host"` // 构建主机
-	BuildOS   string `json:"build_os"`   // 构建操作系统
-	BuildArch string `json:"build_arch"` // 构建架构
-}
-
 func main() {
 	flag.Parse()
 
@@ -70,7 +67,44 @@ func main() {
 	goVersion := runtime.Version()
 	log("Go version: %s", goVersion)
 
-	// 2. 构建插件
+	// 2. 检测插件实现的钩子
+	// 无法检测时写入 0（全部钩子），与未使用构建工具时的行为一致，不影响构建
+	hooks, pluginType, err := detectHooks(absDir)
+	switch {
+	case err != nil:
+		hooks = 0
+		log("Warning: cannot detect hooks: %v; writing an empty mask, the host will call every hook", err)
+	case hooks == 0:
+		log("Plugin type: %s, hooks: %s", pluginType, hooks)
+		log("Warning: %s does not override any hook; an empty mask means all hooks, so the host will call the BasePlugin defaults", pluginType)
+	default:
+		log("Plugin type: %s, hooks: %s", pluginType, hooks)
+	}
+
+	// 3. 校验配置 Schema 与示例配置，失败时不构建
+	schema, err := loadConfigSchema(absDir)
+	if err != nil {
+		fatal("Invalid %s: %v", pluginapi.ConfigSchemaFile, err)
+	}
+	if *sampleCfg != "" {
+		if schema == nil {
+			fatal("-config given but %s has no %s", absDir, pluginapi.ConfigSchemaFile)
+		}
+		sample, err := os.ReadFile(*sampleCfg)
+		if err != nil {
+			fatal("Failed to read sample config: %v", err)
+		}
+		doc, err := loader.ConfigJSON(sample)
+		if err == nil {
+			err = pluginapi.ValidateConfig(schema, doc)
+		}
+		if err != nil {
+			fatal("Sample config %s: %v", *sampleCfg, err)
+		}
+		log("Sample config OK: %s", *sampleCfg)
+	}
+
+	// 4. 构建插件
 	log("Running: go build -buildmode=plugin")
 	if *targetOS != "" || *targetArch != "" {
 		log("Target: %s/%s", defaultIfEmpty(*targetOS, runtime.GOOS), defaultIfEmpty(*targetArch, runtime.GOARCH))
@@ -98,28 +132,31 @@ func main() {
 
 	log("Build successful: %s", absOutput)
 
-	// 3. 尝试加载插件获取元信息（可选，用于生成更完整的 meta）
+	// 5. 尝试加载插件获取元信息（可选，用于生成更完整的 meta）
 	// 注意：由于我们在构建工具中加载，Go 版本一定是匹配的
 	pluginName := strings.TrimSuffix(filepath.Base(absOutput), ".so")
 	hostname, _ := os.Hostname()
 
 	buildOS := defaultIfEmpty(*targetOS, runtime.GOOS)
 	buildArch := defaultIfEmpty(*targetArch, runtime.GOARCH)
-	meta := BuildMeta{
+	// 与主程序加载时读取的格式一致，见 loader.Sidecar
+	meta := loader.Sidecar{
 		PluginMeta: pluginapi.PluginMeta{
-			Name:       pluginName,
-			Version:    "1.0.0", // 默认版本，实际应从插件 Info() 获取
-			SDKVersion: pluginapi.SDKVersion,
-			GoVersion:  goVersion,
-			BuildTime:  time.Now().Format(time.RFC3339),
+			Name:         pluginName,
+			Version:      "1.0.0", // 默认版本，实际应从插件 Info() 获取
+			SDKVersion:   pluginapi.SDKVersion,
+			GoVersion:    goVersion,
+			BuildTime:    time.Now().Format(time.RFC3339),
+			Hooks:        hooks,
+			ConfigSchema: schema,
 		},
 		BuildHost: hostname,
 		BuildOS:   buildOS,
 		BuildArch: buildArch,
 	}
 
-	// 4. 写入元数据文件
-	metaPath := absOutput + ".meta.json"
+	// 6. 写入元数据文件
+	metaPath := absOutput + loader.MetaSuffix
 	metaData, err := json.MarshalIndent(meta, "", "  ")
 	if err != nil {
 		fatal("Failed to marshal meta: %v", err)
@@ -134,6 +171,30 @@ func main() {
 	log("Build complete!")
 	log("  Plugin: %s", absOutput)
 	log("  Meta:   %s", metaPath)
+	log("  Hooks:  %s", hooks)
+	if schema != nil {
+		log("  Schema: %s", pluginapi.ConfigSchemaFile)
+	}
+}
+
+// loadConfigSchema 读取插件目录中的配置 Schema 并校验，不存在时返回 nil
+// 内容原样写入 .meta.json，去除多余空白
+func loadConfigSchema(dir string) (json.RawMessage, error) {
+	data, err := os.ReadFile(filepath.Join(dir, pluginapi.ConfigSchemaFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := pluginapi.CheckConfigSchema(data); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func log(format string, args ...interface{}) {